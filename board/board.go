@@ -3,12 +3,21 @@ package board
 
 import (
 	"errors"
+	"sort"
 )
 
 var (
 	ErrBoxOccupied         = errors.New("box is occupied and cannot be filled")
+	ErrOutOfBounds         = errors.New("box is outside of the board")
+	ErrInvalidContent      = errors.New("box can only be filled with a player's symbol")
 	ErrInvalidDimension    = errors.New("dimensions cannot be negative or 0")
 	ErrInvalidWinCondition = errors.New("number of boxes to fill to win cannot be negative or 0")
+	ErrUnreachableWinCount = errors.New("number of boxes to fill to win cannot be more than the boxes in a row or column")
+	ErrBoxNotSupported     = errors.New("box must be the lowest empty box of its column when pieces fall with gravity")
+	ErrColumnFull          = errors.New("column is full and cannot be filled")
+	ErrNothingToUndo       = errors.New("no move has been made that can be undone")
+	ErrNothingToRedo       = errors.New("no move has been undone that can be redone")
+	ErrInvalidBoxes        = errors.New("boxes do not match the dimensions of the board")
 )
 
 // BoxContent is the state of a tic tac toe box
@@ -19,13 +28,72 @@ const (
 	E BoxContent = iota // Empty box
 	X                   // Box with a x symbol
 	O                   // Box with a o symbol
+	T                   // Box with a triangle symbol
+	S                   // Box with a square symbol
 )
 
-// Board is a nxn matrix defined by user input
+// PlayerSymbols are the symbols players fill boxes with, in the order they are handed out to players
+var PlayerSymbols = []BoxContent{X, O, T, S}
+
+// BoxContentFormat is how a box content is written down by the formats the game is read from and written to
+type BoxContentFormat struct {
+	Character byte   // used in position strings and network messages, position strings write runs of empty boxes as numbers instead
+	Name      string // used in JSON and the HTTP API
+}
+
+// BoxContentFormats are how every box content is written down in position strings, JSON, network messages and the HTTP API
+// the terminal and web views draw the symbols in their own way
+var BoxContentFormats = map[BoxContent]BoxContentFormat{
+	E: {'.', ""},
+	X: {'x', "x"},
+	O: {'o', "o"},
+	T: {'t', "triangle"},
+	S: {'s', "square"},
+}
+
+// SymbolFromCharacter returns the player symbol written with a character, the character of an empty box is not a symbol
+func SymbolFromCharacter(c byte) (BoxContent, bool) {
+	for content, format := range BoxContentFormats {
+		if format.Character == c && content.isPlayerSymbol() {
+			return content, true
+		}
+	}
+
+	return E, false
+}
+
+// isPlayerSymbol checks if the box content is a symbol that a player can fill a box with
+func (c BoxContent) isPlayerSymbol() bool {
+	return c >= X && c <= S
+}
+
+// Board is a mxn matrix defined by user input
 type Board struct {
 	WinCount           int
+	Gravity            bool // pieces fall to the lowest empty box of a column, as in connect four
+	Misere             bool // the player who fills WinCount boxes in a line loses instead of winning
 	Boxes              [][]BoxContent
 	WinConditionChecks []winConditionCheck
+	history            []Move // moves made on the board in the order they were made
+	undone             []Move // moves taken back with Undo, the most recently undone move is last
+	// hashes are the zobrist hashes of the boxes seen through every symmetry, indexed by Symmetry, an empty board hashes to 0
+	hashes [symmetryCount]uint64
+	// openLine is the run CanCompleteLine last found open, it is checked first the next time since it stays open until a move blocks it
+	openLine run
+}
+
+// run is the run of WinCount boxes starting from the box on a particular row and col idx along the ith of the WinConditionChecks
+type run struct {
+	rowIdx int
+	colIdx int
+	check  int
+}
+
+// Move is a player's symbol placed into a box on a particular row and col idx
+type Move struct {
+	RowIdx  int        `json:"row"`
+	ColIdx  int        `json:"col"`
+	Content BoxContent `json:"symbol"`
 }
 
 // direction determines how to traverse in the tic tac toe board when checking if a player has won
@@ -49,13 +117,89 @@ type check struct {
 // for example, checking the row involves checking both right and left direction from a specific box position
 // this should always contain a two element slice check
 type winConditionCheck struct {
-	checks [2]check
+	lineDirection LineDirection
+	checks        [2]check
+}
+
+// LineDirection is the way a line of boxes runs across the tic tac toe board
+type LineDirection int
+
+// Directions a winning line can run in
+const (
+	Row          LineDirection = iota // Line along a row, from left to right
+	Column                            // Line along a column, from top to bottom
+	Diagonal                          // Line from the top left to the bottom right
+	AntiDiagonal                      // Line from the top right to the bottom left
+)
+
+// String returns the readable name of a line direction
+func (d LineDirection) String() string {
+	switch d {
+	case Row:
+		return "row"
+	case Column:
+		return "column"
+	case Diagonal:
+		return "diagonal"
+	case AntiDiagonal:
+		return "anti-diagonal"
+	default:
+		return "unknown"
+	}
+}
+
+// Position is the row and col idx of a box on the board
+type Position struct {
+	RowIdx int
+	ColIdx int
+}
+
+// OrderByCentre returns every position on a board with the number of rows and cols sorted from the centre outwards
+// boxes near the centre take part in more lines, so searches trying them first let alpha-beta prune earlier
+func OrderByCentre(rows, cols int) []Position {
+	order := make([]Position, 0, rows*cols)
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			order = append(order, Position{row, col})
+		}
+	}
+
+	distance := func(p Position) int {
+		rowDistance := 2*p.RowIdx - (rows - 1)
+		colDistance := 2*p.ColIdx - (cols - 1)
+		return rowDistance*rowDistance + colDistance*colDistance
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return distance(order[i]) < distance(order[j])
+	})
+
+	return order
+}
+
+// WinningLine is the run of boxes filled with the same symbol that has won the game
+type WinningLine struct {
+	Direction LineDirection
+	Positions []Position // boxes of the run in the order given by Direction
 }
 
 // NewBoardParams defines the structure for the parameters needed to create a new board
 type NewBoardParams struct {
-	WinCount   int
-	Dimensions int
+	WinCount int
+	Rows     int
+	Cols     int
+	Gravity  bool
+	Misere   bool
+}
+
+// NewBoardWithBoxesParams defines the structure for the parameters needed to create a board with its boxes already filled
+type NewBoardWithBoxesParams struct {
+	WinCount int
+	Rows     int
+	Cols     int
+	Gravity  bool
+	Misere   bool
+	Boxes    [][]BoxContent // rows of boxes from top to bottom, each holding Cols boxes
 }
 
 // CheckForWinnerParams defines the structure for the parameters needed to check for a winner
@@ -78,27 +222,33 @@ type InsertBoxWithContentParams struct {
 	Content BoxContent
 }
 
+// DropPieceParams defines the structure for the parameters needed to drop a player's symbol into a column of a board with gravity
+type DropPieceParams struct {
+	ColIdx  int
+	Content BoxContent
+}
+
 // NewBoard creates a new tic tac toe board
 func NewBoard(p NewBoardParams) (*Board, error) {
 
-	if p.WinCount <= 0 {
-		return nil, ErrInvalidWinCondition
-	} else if p.Dimensions <= 0 {
-		return nil, ErrInvalidDimension
+	if err := checkBoardParams(p); err != nil {
+		return nil, err
 	}
 
 	winConditionChecks := generateChecks()
 
 	b := &Board{
-		p.WinCount,
-		make([][]BoxContent, p.Dimensions),
-		winConditionChecks,
+		WinCount:           p.WinCount,
+		Gravity:            p.Gravity,
+		Misere:             p.Misere,
+		Boxes:              make([][]BoxContent, p.Rows),
+		WinConditionChecks: winConditionChecks,
 	}
 
 	// fill box with empty content
-	for row := 0; row < p.Dimensions; row++ {
-		b.Boxes[row] = make([]BoxContent, p.Dimensions)
-		for col := 0; col < p.Dimensions; col++ {
+	for row := 0; row < p.Rows; row++ {
+		b.Boxes[row] = make([]BoxContent, p.Cols)
+		for col := 0; col < p.Cols; col++ {
 			b.Boxes[row][col] = E
 		}
 	}
@@ -106,58 +256,289 @@ func NewBoard(p NewBoardParams) (*Board, error) {
 	return b, nil
 }
 
-// SelectBox inserts a player's symbol into a box on a particular row and col idx
-func (b Board) SelectBox(p InsertBoxWithContentParams) error {
-	if b.Boxes[p.RowIdx][p.ColIdx] != E {
+// NewBoardWithBoxes creates a board with its boxes filled as given and no move history, such as a board read from a file or a message
+// the boxes are checked against the dimensions before any box is allocated, so that dimensions far bigger than the boxes given are refused
+func NewBoardWithBoxes(p NewBoardWithBoxesParams) (*Board, error) {
+	newBoardParams := NewBoardParams{
+		WinCount: p.WinCount,
+		Rows:     p.Rows,
+		Cols:     p.Cols,
+		Gravity:  p.Gravity,
+		Misere:   p.Misere,
+	}
+
+	if err := checkBoardParams(newBoardParams); err != nil {
+		return nil, err
+	} else if len(p.Boxes) != p.Rows {
+		return nil, ErrInvalidBoxes
+	}
+
+	for row := range p.Boxes {
+		if len(p.Boxes[row]) != p.Cols {
+			return nil, ErrInvalidBoxes
+		}
+
+		for _, content := range p.Boxes[row] {
+			if content != E && !content.isPlayerSymbol() {
+				return nil, ErrInvalidContent
+			}
+		}
+	}
+
+	b, err := NewBoard(newBoardParams)
+	if err != nil {
+		return nil, err
+	}
+
+	for row := range p.Boxes {
+		copy(b.Boxes[row], p.Boxes[row])
+	}
+	b.RecomputeHash()
+
+	return b, nil
+}
+
+// checkBoardParams checks that a board can be created with the win count and dimensions
+func checkBoardParams(p NewBoardParams) error {
+	if p.WinCount <= 0 {
+		return ErrInvalidWinCondition
+	} else if p.Rows <= 0 || p.Cols <= 0 {
+		return ErrInvalidDimension
+	} else if p.WinCount > p.Rows && p.WinCount > p.Cols {
+		return ErrUnreachableWinCount
+	}
+
+	return nil
+}
+
+// Rows returns the number of rows on the board
+func (b *Board) Rows() int {
+	return len(b.Boxes)
+}
+
+// Cols returns the number of columns on the board
+func (b *Board) Cols() int {
+	return len(b.Boxes[0])
+}
+
+// SelectBox inserts a player's symbol into a box on a particular row and col idx and records it in the move history
+// making a new move discards any moves that were undone and not redone
+func (b *Board) SelectBox(p InsertBoxWithContentParams) error {
+	if !b.isWithinBounds(p.RowIdx, p.ColIdx) {
+		return ErrOutOfBounds
+	} else if !p.Content.isPlayerSymbol() {
+		return ErrInvalidContent
+	} else if b.Boxes[p.RowIdx][p.ColIdx] != E {
 		return ErrBoxOccupied
+	} else if !b.IsPlayable(p.RowIdx, p.ColIdx) {
+		return ErrBoxNotSupported
 	}
 
 	b.Boxes[p.RowIdx][p.ColIdx] = p.Content
+	b.toggleHash(p.RowIdx, p.ColIdx, p.Content)
+	b.history = append(b.history, Move{p.RowIdx, p.ColIdx, p.Content})
+	b.undone = b.undone[:0]
 
 	return nil
 }
 
+// DropPiece drops a player's symbol into a column so that it lands on the lowest empty box, and returns the row idx it landed on
+func (b *Board) DropPiece(p DropPieceParams) (int, error) {
+	if p.ColIdx < 0 || p.ColIdx >= b.Cols() {
+		return 0, ErrOutOfBounds
+	}
+
+	for row := b.Rows() - 1; row >= 0; row-- {
+		if b.Boxes[row][p.ColIdx] != E {
+			continue
+		}
+
+		insertBoxWithContentParams := InsertBoxWithContentParams{
+			RowIdx:  row,
+			ColIdx:  p.ColIdx,
+			Content: p.Content,
+		}
+
+		if err := b.SelectBox(insertBoxWithContentParams); err != nil {
+			return 0, err
+		}
+
+		return row, nil
+	}
+
+	return 0, ErrColumnFull
+}
+
+// IsPlayable checks if the box on a particular row and col idx can be filled by the next move
+// the box has to be on the board and empty, and with gravity it also has to rest on the bottom row or on a filled box
+func (b *Board) IsPlayable(rowIdx, colIdx int) bool {
+	if !b.isWithinBounds(rowIdx, colIdx) || b.Boxes[rowIdx][colIdx] != E {
+		return false
+	}
+
+	if b.Gravity && rowIdx < b.Rows()-1 && b.Boxes[rowIdx+1][colIdx] == E {
+		return false
+	}
+
+	return true
+}
+
+// Undo empties the box filled by the last move and returns that move
+func (b *Board) Undo() (Move, error) {
+	if len(b.history) == 0 {
+		return Move{}, ErrNothingToUndo
+	}
+
+	move := b.history[len(b.history)-1]
+	b.history = b.history[:len(b.history)-1]
+	b.undone = append(b.undone, move)
+
+	b.Boxes[move.RowIdx][move.ColIdx] = E
+	b.toggleHash(move.RowIdx, move.ColIdx, move.Content)
+
+	return move, nil
+}
+
+// Redo fills the box of the last undone move again and returns that move
+func (b *Board) Redo() (Move, error) {
+	if len(b.undone) == 0 {
+		return Move{}, ErrNothingToRedo
+	}
+
+	move := b.undone[len(b.undone)-1]
+	b.undone = b.undone[:len(b.undone)-1]
+	b.history = append(b.history, move)
+
+	b.Boxes[move.RowIdx][move.ColIdx] = move.Content
+	b.toggleHash(move.RowIdx, move.ColIdx, move.Content)
+
+	return move, nil
+}
+
+// History returns the moves made on the board in the order they were made, undone moves are not included
+func (b *Board) History() []Move {
+	history := make([]Move, len(b.history))
+	copy(history, b.history)
+
+	return history
+}
+
+// Clone returns a copy of the board that shares none of the boxes or moves, so that moves made on one are never seen by the other
+// a copy of Board made by assignment still shares its Boxes, only a clone can be searched in another goroutine while the board is used
+func (b *Board) Clone() *Board {
+	clone := *b
+
+	clone.Boxes = make([][]BoxContent, len(b.Boxes))
+	for row := range b.Boxes {
+		clone.Boxes[row] = make([]BoxContent, len(b.Boxes[row]))
+		copy(clone.Boxes[row], b.Boxes[row])
+	}
+
+	clone.history = make([]Move, len(b.history))
+	copy(clone.history, b.history)
+	clone.undone = make([]Move, len(b.undone))
+	copy(clone.undone, b.undone)
+
+	return &clone
+}
+
 // CheckForWinner checks for all possible win conditions from a player's position in a box of a specific row and col index
-func (b Board) CheckForWinner(p CheckForWinnerParams) bool {
+// with misere rules a line found this way means the player has lost instead
+func (b *Board) CheckForWinner(p CheckForWinnerParams) bool {
+	_, won := b.FindWinningLine(p)
+
+	return won
+}
+
+// FindWinningLine checks for all possible win conditions from a player's position in a box of a specific row and col index
+// and returns the full run of the player's symbol through that box when it is long enough to win
+func (b *Board) FindWinningLine(p CheckForWinnerParams) (WinningLine, bool) {
 
 	for _, winConditionCheck := range b.WinConditionChecks {
-		consecutivePlayerSymbolFound := 1
+		forward := winConditionCheck.checks[0]
+		backward := winConditionCheck.checks[1]
 
-		for _, check := range winConditionCheck.checks {
-			for i := 1; i < b.WinCount; i++ {
+		forwardCount := b.countConsecutive(p, forward)
+		backwardCount := b.countConsecutive(p, backward)
 
-				checkRowIdx := p.RowIdx + (int(check.rowDirection) * i)
-				checkColIdx := p.ColIdx + (int(check.colDirection) * i)
+		if backwardCount+1+forwardCount < b.WinCount {
+			continue
+		}
 
-				// skip any checks that has exceeded the tic tac toe board's dimensions
-				if checkRowIdx >= len(b.Boxes) || checkRowIdx < 0 || checkColIdx >= len(b.Boxes[0]) || checkColIdx < 0 {
-					break
-				}
+		// the run starts at the furthest box found when walking backwards
+		startRowIdx := p.RowIdx + (int(backward.rowDirection) * backwardCount)
+		startColIdx := p.ColIdx + (int(backward.colDirection) * backwardCount)
 
-				getBoxContentParams := GetBoxContentParams{
-					RowIdx: checkRowIdx,
-					ColIdx: checkColIdx,
-				}
+		positions := make([]Position, backwardCount+1+forwardCount)
+		for i := range positions {
+			positions[i] = Position{
+				RowIdx: startRowIdx + (int(forward.rowDirection) * i),
+				ColIdx: startColIdx + (int(forward.colDirection) * i),
+			}
+		}
 
-				if b.getBoxContent(getBoxContentParams) != p.PlayerSymbol {
-					break
-				}
+		return WinningLine{winConditionCheck.lineDirection, positions}, true
+	}
+
+	return WinningLine{}, false
+}
 
-				consecutivePlayerSymbolFound++
-				if consecutivePlayerSymbolFound == b.WinCount {
+// CanCompleteLine checks if any run of WinCount boxes can still be filled with a single symbol, which is when it holds no more than one symbol
+// once no run can be completed the game can only end in a draw, however many players there are and whether misere rules are played
+func (b *Board) CanCompleteLine() bool {
+	if r := b.openLine; b.isWithinBounds(r.rowIdx, r.colIdx) && r.check < len(b.WinConditionChecks) {
+		if _, _, open := b.openRun(r.rowIdx, r.colIdx, b.WinConditionChecks[r.check].checks[0]); open {
+			return true
+		}
+	}
+
+	for row := range b.Boxes {
+		for col := range b.Boxes[row] {
+			for i, winConditionCheck := range b.WinConditionChecks {
+				if _, _, open := b.openRun(row, col, winConditionCheck.checks[0]); open {
+					b.openLine = run{row, col, i}
 					return true
 				}
 			}
+		}
+	}
+
+	return false
+}
 
+// countConsecutive returns the number of consecutive boxes filled with the player's symbol next to the player's box along a check path
+func (b *Board) countConsecutive(p CheckForWinnerParams, c check) int {
+	count := 0
+
+	for i := 1; ; i++ {
+		checkRowIdx := p.RowIdx + (int(c.rowDirection) * i)
+		checkColIdx := p.ColIdx + (int(c.colDirection) * i)
+
+		// stop at the edges of the tic tac toe board
+		if !b.isWithinBounds(checkRowIdx, checkColIdx) {
+			return count
+		}
+
+		getBoxContentParams := GetBoxContentParams{
+			RowIdx: checkRowIdx,
+			ColIdx: checkColIdx,
 		}
 
+		if b.getBoxContent(getBoxContentParams) != p.PlayerSymbol {
+			return count
+		}
+
+		count++
 	}
+}
 
-	return false
+// isWithinBounds checks if a row and col index points to a box on the board
+func (b *Board) isWithinBounds(rowIdx, colIdx int) bool {
+	return rowIdx >= 0 && rowIdx < b.Rows() && colIdx >= 0 && colIdx < b.Cols()
 }
 
 // getBoxContent returns the symbol contained within a box of a particular row and col index
-func (b Board) getBoxContent(p GetBoxContentParams) BoxContent {
+func (b *Board) getBoxContent(p GetBoxContentParams) BoxContent {
 	return b.Boxes[p.RowIdx][p.ColIdx]
 }
 
@@ -166,24 +547,28 @@ func (b Board) getBoxContent(p GetBoxContentParams) BoxContent {
 func generateChecks() []winConditionCheck {
 
 	winViaRowChecks := winConditionCheck{
+		lineDirection: Row,
 		checks: [2]check{
 			check{stay, right},
 			check{stay, left},
 		},
 	}
 	winViaColChecks := winConditionCheck{
+		lineDirection: Column,
 		checks: [2]check{
 			check{down, stay},
 			check{up, stay},
 		},
 	}
 	winViaDiagonalChecks := winConditionCheck{
+		lineDirection: Diagonal,
 		checks: [2]check{
 			check{down, right},
 			check{up, left},
 		},
 	}
 	winViaReverseDiagonalChecks := winConditionCheck{
+		lineDirection: AntiDiagonal,
 		checks: [2]check{
 			check{down, left},
 			check{up, right},
@@ -9,8 +9,9 @@ var winConditionChecks = generateChecks()
 
 func TestNewBoard(t *testing.T) {
 	type args struct {
-		winCount   int
-		dimensions int
+		winCount int
+		rows     int
+		cols     int
 	}
 
 	type want struct {
@@ -28,6 +29,7 @@ func TestNewBoard(t *testing.T) {
 			args{
 				3,
 				-1,
+				-1,
 			},
 			want{
 				ErrInvalidDimension,
@@ -39,6 +41,7 @@ func TestNewBoard(t *testing.T) {
 			args{
 				3,
 				0,
+				0,
 			},
 			want{
 				ErrInvalidDimension,
@@ -50,6 +53,7 @@ func TestNewBoard(t *testing.T) {
 			args{
 				-1,
 				3,
+				3,
 			},
 			want{
 				ErrInvalidWinCondition,
@@ -61,28 +65,91 @@ func TestNewBoard(t *testing.T) {
 			args{
 				0,
 				3,
+				3,
 			},
 			want{
 				ErrInvalidWinCondition,
 				nil,
 			},
 		},
+		{
+			"returns error when cols to create board is zero",
+			args{
+				3,
+				3,
+				0,
+			},
+			want{
+				ErrInvalidDimension,
+				nil,
+			},
+		},
+		{
+			"returns error when winCount is longer than both rows and cols",
+			args{
+				4,
+				3,
+				3,
+			},
+			want{
+				ErrUnreachableWinCount,
+				nil,
+			},
+		},
+		{
+			"creates a board when winCount only fits along the cols",
+			args{
+				4,
+				1,
+				4,
+			},
+			want{
+				nil,
+				&Board{
+					WinCount: 4,
+					Boxes: [][]BoxContent{
+						{E, E, E, E},
+					},
+					WinConditionChecks: winConditionChecks,
+				},
+			},
+		},
+		{
+			"creates an empty board of 2x4 dimension",
+			args{
+				2,
+				2,
+				4,
+			},
+			want{
+				nil,
+				&Board{
+					WinCount: 2,
+					Boxes: [][]BoxContent{
+						{E, E, E, E},
+						{E, E, E, E},
+					},
+					WinConditionChecks: winConditionChecks,
+				},
+			},
+		},
 		{
 			"creates an empty board of 3x3 dimension",
 			args{
 				3,
 				3,
+				3,
 			},
 			want{
 				nil,
 				&Board{
-					3,
-					[][]BoxContent{
+					WinCount: 3,
+					Boxes: [][]BoxContent{
 						{E, E, E},
 						{E, E, E},
 						{E, E, E},
 					},
-					winConditionChecks,
+					WinConditionChecks: winConditionChecks,
 				},
 			},
 		},
@@ -91,130 +158,746 @@ func TestNewBoard(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			newBoardParams := NewBoardParams{
-				WinCount:   test.args.winCount,
-				Dimensions: test.args.dimensions,
+				WinCount: test.args.winCount,
+				Rows:     test.args.rows,
+				Cols:     test.args.cols,
 			}
 
 			getBoard, err := NewBoard(newBoardParams)
 
-			if !reflect.DeepEqual(err, test.want.err) {
-				t.Errorf("unexpected error = %v, want %v", err, test.want.err)
-			}
+			if !reflect.DeepEqual(err, test.want.err) {
+				t.Errorf("unexpected error = %v, want %v", err, test.want.err)
+			}
+
+			if !reflect.DeepEqual(getBoard, test.want.expectBoard) {
+				t.Errorf("unexpected Board = %v, want %v", getBoard, test.want.expectBoard)
+			}
+
+		})
+	}
+
+}
+
+func TestNewBoardWithBoxes(t *testing.T) {
+	type args struct {
+		rows  int
+		cols  int
+		boxes [][]BoxContent
+	}
+
+	type want struct {
+		err   error
+		boxes [][]BoxContent
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"returns error when dimension to create board is zero",
+			args{
+				0,
+				3,
+				nil,
+			},
+			want{
+				ErrInvalidDimension,
+				nil,
+			},
+		},
+		{
+			"returns error when the number of rows is far bigger than the rows given",
+			args{
+				1000000,
+				1000000,
+				[][]BoxContent{{E, E, E}},
+			},
+			want{
+				ErrInvalidBoxes,
+				nil,
+			},
+		},
+		{
+			"returns error when a row is shorter than the cols",
+			args{
+				2,
+				3,
+				[][]BoxContent{{E, E, E}, {X, O}},
+			},
+			want{
+				ErrInvalidBoxes,
+				nil,
+			},
+		},
+		{
+			"returns error when a box holds no known content",
+			args{
+				1,
+				3,
+				[][]BoxContent{{E, S + 1, E}},
+			},
+			want{
+				ErrInvalidContent,
+				nil,
+			},
+		},
+		{
+			"creates a board holding the boxes",
+			args{
+				2,
+				3,
+				[][]BoxContent{{E, E, T}, {X, O, E}},
+			},
+			want{
+				nil,
+				[][]BoxContent{{E, E, T}, {X, O, E}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			newBoardWithBoxesParams := NewBoardWithBoxesParams{
+				WinCount: 3,
+				Rows:     test.args.rows,
+				Cols:     test.args.cols,
+				Boxes:    test.args.boxes,
+			}
+
+			gotBoard, err := NewBoardWithBoxes(newBoardWithBoxesParams)
+
+			if !reflect.DeepEqual(err, test.want.err) {
+				t.Errorf("unexpected error = %v, want %v", err, test.want.err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(gotBoard.Boxes, test.want.boxes) {
+				t.Errorf("unexpected Boxes = %v, want %v", gotBoard.Boxes, test.want.boxes)
+			}
+
+			// the board is built move by move to check that the hash is worked out from the boxes given
+			expectBoard, _ := NewBoard(NewBoardParams{WinCount: 3, Rows: test.args.rows, Cols: test.args.cols})
+			for row := range test.want.boxes {
+				for col, content := range test.want.boxes[row] {
+					if content != E {
+						expectBoard.SelectBox(InsertBoxWithContentParams{row, col, content})
+					}
+				}
+			}
+
+			if gotBoard.Hash() != expectBoard.Hash() {
+				t.Errorf("unexpected hash = %d, want %d", gotBoard.Hash(), expectBoard.Hash())
+			}
+
+		})
+	}
+
+}
+
+func TestSelectBox(t *testing.T) {
+	type args struct {
+		rowIdx  int
+		colIdx  int
+		content BoxContent
+	}
+
+	type fields struct {
+		WinCount           int
+		Boxes              [][]BoxContent
+		WinConditionChecks []winConditionCheck
+	}
+
+	type want struct {
+		err         error
+		expectBoard Board
+	}
+
+	tests := []struct {
+		name   string
+		args   args
+		fields fields
+		want   want
+	}{
+		{
+			"returns no error when inserting content into unfilled box",
+			args{
+				0,
+				0,
+				X,
+			},
+			fields{
+				3,
+				[][]BoxContent{
+					{E, E, E},
+					{E, E, E},
+					{E, E, E},
+				},
+				winConditionChecks,
+			},
+			want{
+				nil,
+				Board{
+					WinCount: 3,
+					Boxes: [][]BoxContent{
+						{X, E, E},
+						{E, E, E},
+						{E, E, E},
+					},
+					WinConditionChecks: winConditionChecks,
+					history:            []Move{{0, 0, X}},
+				},
+			},
+		},
+		{
+			"returns error when inserting content into filled box",
+			args{
+				0,
+				0,
+				X,
+			},
+			fields{
+				3,
+				[][]BoxContent{
+					{X, E, E},
+					{E, E, E},
+					{E, E, E},
+				},
+				winConditionChecks,
+			},
+			want{
+				ErrBoxOccupied,
+				Board{
+					WinCount: 3,
+					Boxes: [][]BoxContent{
+						{X, E, E},
+						{E, E, E},
+						{E, E, E},
+					},
+					WinConditionChecks: winConditionChecks,
+				},
+			},
+		},
+		{
+			"returns error when row index is outside of the board",
+			args{
+				3,
+				0,
+				X,
+			},
+			fields{
+				3,
+				[][]BoxContent{
+					{E, E, E},
+					{E, E, E},
+					{E, E, E},
+				},
+				winConditionChecks,
+			},
+			want{
+				ErrOutOfBounds,
+				Board{
+					WinCount: 3,
+					Boxes: [][]BoxContent{
+						{E, E, E},
+						{E, E, E},
+						{E, E, E},
+					},
+					WinConditionChecks: winConditionChecks,
+				},
+			},
+		},
+		{
+			"returns error when col index is negative",
+			args{
+				0,
+				-1,
+				X,
+			},
+			fields{
+				3,
+				[][]BoxContent{
+					{E, E, E},
+					{E, E, E},
+					{E, E, E},
+				},
+				winConditionChecks,
+			},
+			want{
+				ErrOutOfBounds,
+				Board{
+					WinCount: 3,
+					Boxes: [][]BoxContent{
+						{E, E, E},
+						{E, E, E},
+						{E, E, E},
+					},
+					WinConditionChecks: winConditionChecks,
+				},
+			},
+		},
+		{
+			"returns error when inserting empty content",
+			args{
+				1,
+				1,
+				E,
+			},
+			fields{
+				3,
+				[][]BoxContent{
+					{E, E, E},
+					{E, E, E},
+					{E, E, E},
+				},
+				winConditionChecks,
+			},
+			want{
+				ErrInvalidContent,
+				Board{
+					WinCount: 3,
+					Boxes: [][]BoxContent{
+						{E, E, E},
+						{E, E, E},
+						{E, E, E},
+					},
+					WinConditionChecks: winConditionChecks,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			testBoard := Board{
+				WinCount:           test.fields.WinCount,
+				Boxes:              test.fields.Boxes,
+				WinConditionChecks: test.fields.WinConditionChecks,
+			}
+			testBoard.RecomputeHash()
+
+			// the hash kept up to date by the move has to match the hash worked out from the boxes
+			test.want.expectBoard.RecomputeHash()
+
+			insertBoxWithContentParams := InsertBoxWithContentParams{
+				RowIdx:  test.args.rowIdx,
+				ColIdx:  test.args.colIdx,
+				Content: test.args.content,
+			}
+
+			if err := testBoard.SelectBox(insertBoxWithContentParams); !reflect.DeepEqual(err, test.want.err) {
+				t.Errorf("unexpected error = %v, want %v", err, test.want.err)
+			}
+
+			if !reflect.DeepEqual(testBoard, test.want.expectBoard) {
+				t.Errorf("unexpected Board = %v, want %v", testBoard, test.want.expectBoard)
+			}
+
+		})
+	}
+
+}
+
+func TestDropPiece(t *testing.T) {
+	type args struct {
+		colIdx  int
+		content BoxContent
+	}
+
+	type want struct {
+		err    error
+		rowIdx int
+		boxes  [][]BoxContent
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		boxes [][]BoxContent
+		want  want
+	}{
+		{
+			"drops the piece to the bottom row of an empty column",
+			args{
+				1,
+				X,
+			},
+			[][]BoxContent{
+				{E, E, E},
+				{E, E, E},
+				{E, E, E},
+			},
+			want{
+				nil,
+				2,
+				[][]BoxContent{
+					{E, E, E},
+					{E, E, E},
+					{E, X, E},
+				},
+			},
+		},
+		{
+			"drops the piece on top of the filled boxes of the column",
+			args{
+				1,
+				O,
+			},
+			[][]BoxContent{
+				{E, E, E},
+				{E, X, E},
+				{E, X, E},
+			},
+			want{
+				nil,
+				0,
+				[][]BoxContent{
+					{E, O, E},
+					{E, X, E},
+					{E, X, E},
+				},
+			},
+		},
+		{
+			"returns error when the column is full",
+			args{
+				0,
+				X,
+			},
+			[][]BoxContent{
+				{O, E, E},
+				{X, E, E},
+				{O, E, E},
+			},
+			want{
+				ErrColumnFull,
+				0,
+				[][]BoxContent{
+					{O, E, E},
+					{X, E, E},
+					{O, E, E},
+				},
+			},
+		},
+		{
+			"returns error when the column is not on the board",
+			args{
+				3,
+				X,
+			},
+			[][]BoxContent{
+				{E, E, E},
+				{E, E, E},
+				{E, E, E},
+			},
+			want{
+				ErrOutOfBounds,
+				0,
+				[][]BoxContent{
+					{E, E, E},
+					{E, E, E},
+					{E, E, E},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testBoard := Board{
+				WinCount:           3,
+				Gravity:            true,
+				Boxes:              test.boxes,
+				WinConditionChecks: winConditionChecks,
+			}
+
+			dropPieceParams := DropPieceParams{
+				ColIdx:  test.args.colIdx,
+				Content: test.args.content,
+			}
+
+			gotRowIdx, err := testBoard.DropPiece(dropPieceParams)
+
+			if !reflect.DeepEqual(err, test.want.err) {
+				t.Errorf("unexpected error = %v, want %v", err, test.want.err)
+			}
+
+			if gotRowIdx != test.want.rowIdx {
+				t.Errorf("unexpected row idx = %d, want %d", gotRowIdx, test.want.rowIdx)
+			}
+
+			if !reflect.DeepEqual(testBoard.Boxes, test.want.boxes) {
+				t.Errorf("unexpected Boxes = %v, want %v", testBoard.Boxes, test.want.boxes)
+			}
+
+		})
+	}
+
+}
+
+func TestSelectBoxWithGravity(t *testing.T) {
+	testBoard := Board{
+		WinCount: 3,
+		Gravity:  true,
+		Boxes: [][]BoxContent{
+			{E, E, E},
+			{E, E, E},
+			{E, X, E},
+		},
+		WinConditionChecks: winConditionChecks,
+	}
+
+	insertBoxWithContentParams := InsertBoxWithContentParams{
+		RowIdx:  0,
+		ColIdx:  1,
+		Content: O,
+	}
+
+	if err := testBoard.SelectBox(insertBoxWithContentParams); err != ErrBoxNotSupported {
+		t.Errorf("unexpected error = %v, want %v", err, ErrBoxNotSupported)
+	}
 
-			if !reflect.DeepEqual(getBoard, test.want.expectBoard) {
-				t.Errorf("unexpected Board = %v, want %v", getBoard, test.want.expectBoard)
-			}
+	insertBoxWithContentParams.RowIdx = 1
 
-		})
+	if err := testBoard.SelectBox(insertBoxWithContentParams); err != nil {
+		t.Errorf("unexpected error = %v, want %v", err, nil)
 	}
 
 }
 
-func TestSelectBox(t *testing.T) {
+func TestUndoRedo(t *testing.T) {
 	type args struct {
-		rowIdx  int
-		colIdx  int
-		content BoxContent
-	}
-
-	type fields struct {
-		WinCount           int
-		Boxes              [][]BoxContent
-		WinConditionChecks []winConditionCheck
+		moves []InsertBoxWithContentParams
+		undos int
+		redos int
 	}
 
 	type want struct {
-		err         error
-		expectBoard Board
+		err     error
+		boxes   [][]BoxContent
+		history []Move
 	}
 
 	tests := []struct {
-		name   string
-		args   args
-		fields fields
-		want   want
+		name string
+		args args
+		want want
 	}{
 		{
-			"returns no error when inserting content into unfilled box",
+			"records every move in the order it was made",
 			args{
+				[]InsertBoxWithContentParams{{0, 0, X}, {1, 1, O}},
 				0,
 				0,
-				X,
 			},
-			fields{
-				3,
+			want{
+				nil,
 				[][]BoxContent{
+					{X, E, E},
+					{E, O, E},
 					{E, E, E},
+				},
+				[]Move{{0, 0, X}, {1, 1, O}},
+			},
+		},
+		{
+			"empties the box of the last move when undoing",
+			args{
+				[]InsertBoxWithContentParams{{0, 0, X}, {1, 1, O}},
+				1,
+				0,
+			},
+			want{
+				nil,
+				[][]BoxContent{
+					{X, E, E},
 					{E, E, E},
 					{E, E, E},
 				},
-				winConditionChecks,
+				[]Move{{0, 0, X}},
+			},
+		},
+		{
+			"fills the box of the undone move again when redoing",
+			args{
+				[]InsertBoxWithContentParams{{0, 0, X}, {1, 1, O}},
+				2,
+				1,
 			},
 			want{
 				nil,
-				Board{
-					3,
-					[][]BoxContent{
-						{X, E, E},
-						{E, E, E},
-						{E, E, E},
-					},
-					winConditionChecks,
+				[][]BoxContent{
+					{X, E, E},
+					{E, E, E},
+					{E, E, E},
 				},
+				[]Move{{0, 0, X}},
 			},
 		},
 		{
-			"returns error when inserting content into filled box",
+			"returns error when there is no move to undo",
 			args{
+				[]InsertBoxWithContentParams{{0, 0, X}},
+				2,
 				0,
-				0,
-				X,
 			},
-			fields{
-				3,
+			want{
+				ErrNothingToUndo,
 				[][]BoxContent{
-					{X, E, E},
+					{E, E, E},
 					{E, E, E},
 					{E, E, E},
 				},
-				winConditionChecks,
+				[]Move{},
+			},
+		},
+		{
+			"returns error when there is no undone move to redo",
+			args{
+				[]InsertBoxWithContentParams{{0, 0, X}},
+				1,
+				2,
 			},
 			want{
-				ErrBoxOccupied,
-				Board{
-					3,
-					[][]BoxContent{
-						{X, E, E},
-						{E, E, E},
-						{E, E, E},
-					},
-					winConditionChecks,
+				ErrNothingToRedo,
+				[][]BoxContent{
+					{X, E, E},
+					{E, E, E},
+					{E, E, E},
 				},
+				[]Move{{0, 0, X}},
 			},
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
+			testBoard, _ := NewBoard(NewBoardParams{WinCount: 3, Rows: 3, Cols: 3})
 
-			testBoard := Board{
-				WinCount:           test.fields.WinCount,
-				Boxes:              test.fields.Boxes,
-				WinConditionChecks: test.fields.WinConditionChecks,
+			for _, move := range test.args.moves {
+				testBoard.SelectBox(move)
 			}
 
-			insertBoxWithContentParams := InsertBoxWithContentParams{
-				RowIdx:  test.args.rowIdx,
-				ColIdx:  test.args.colIdx,
-				Content: test.args.content,
+			var err error
+			for i := 0; i < test.args.undos; i++ {
+				_, err = testBoard.Undo()
+			}
+			for i := 0; i < test.args.redos; i++ {
+				_, err = testBoard.Redo()
 			}
 
-			if err := testBoard.SelectBox(insertBoxWithContentParams); !reflect.DeepEqual(err, test.want.err) {
+			if !reflect.DeepEqual(err, test.want.err) {
 				t.Errorf("unexpected error = %v, want %v", err, test.want.err)
 			}
 
-			if !reflect.DeepEqual(testBoard, test.want.expectBoard) {
-				t.Errorf("unexpected Board = %v, want %v", testBoard, test.want.expectBoard)
+			if !reflect.DeepEqual(testBoard.Boxes, test.want.boxes) {
+				t.Errorf("unexpected Boxes = %v, want %v", testBoard.Boxes, test.want.boxes)
+			}
+
+			if history := testBoard.History(); !reflect.DeepEqual(history, test.want.history) {
+				t.Errorf("unexpected History = %v, want %v", history, test.want.history)
+			}
+
+		})
+	}
+
+}
+
+func TestClone(t *testing.T) {
+	testBoard, _ := NewBoard(NewBoardParams{WinCount: 3, Rows: 3, Cols: 3})
+	testBoard.SelectBox(InsertBoxWithContentParams{0, 0, X})
+	testBoard.SelectBox(InsertBoxWithContentParams{1, 1, O})
+	testBoard.Undo()
+
+	clone := testBoard.Clone()
+	if !reflect.DeepEqual(clone, testBoard) {
+		t.Errorf("unexpected clone = %v, want %v", clone, testBoard)
+	}
+
+	// moves made on the clone are not seen by the board, and the board can still redo the move it took back
+	clone.SelectBox(InsertBoxWithContentParams{2, 2, O})
+
+	wantBoxes := [][]BoxContent{
+		{X, E, E},
+		{E, E, E},
+		{E, E, E},
+	}
+	if !reflect.DeepEqual(testBoard.Boxes, wantBoxes) {
+		t.Errorf("unexpected Boxes = %v, want %v", testBoard.Boxes, wantBoxes)
+	}
+
+	if history := testBoard.History(); !reflect.DeepEqual(history, []Move{{0, 0, X}}) {
+		t.Errorf("unexpected History = %v, want %v", history, []Move{{0, 0, X}})
+	}
+
+	if hash, cloneHash := testBoard.Hash(), clone.Hash(); hash == cloneHash {
+		t.Errorf("unexpected Hash = %d, want it to differ from the clone hash %d", hash, cloneHash)
+	}
+
+	if move, err := testBoard.Redo(); err != nil || move != (Move{1, 1, O}) {
+		t.Errorf("unexpected Redo = %v, %v, want %v, %v", move, err, Move{1, 1, O}, nil)
+	}
+
+}
+
+func TestBoxContentFormats(t *testing.T) {
+	characters := make(map[byte]BoxContent)
+	names := make(map[string]BoxContent)
+
+	for _, content := range append([]BoxContent{E}, PlayerSymbols...) {
+		format, ok := BoxContentFormats[content]
+		if !ok {
+			t.Fatalf("unexpected missing format for %d", content)
+		}
+
+		// every box content has to be told apart from the others in every format
+		if other, ok := characters[format.Character]; ok {
+			t.Errorf("unexpected character %c = %d and %d, want a character for each", format.Character, other, content)
+		}
+		if other, ok := names[format.Name]; ok {
+			t.Errorf("unexpected name %q = %d and %d, want a name for each", format.Name, other, content)
+		}
+		characters[format.Character] = content
+		names[format.Name] = content
+
+		symbol, ok := SymbolFromCharacter(format.Character)
+		if wantOK := content != E; ok != wantOK || (ok && symbol != content) {
+			t.Errorf("unexpected symbol from %c = %d, %v, want %d, %v", format.Character, symbol, ok, content, wantOK)
+		}
+	}
+
+}
+
+func TestOrderByCentre(t *testing.T) {
+	tests := []struct {
+		name string
+		rows int
+		cols int
+		want []Position
+	}{
+		{
+			"starts from the centre box of a 3*3 board and ends with the corners",
+			3,
+			3,
+			[]Position{{1, 1}, {0, 1}, {1, 0}, {1, 2}, {2, 1}, {0, 0}, {0, 2}, {2, 0}, {2, 2}},
+		},
+		{
+			"starts from the two middle boxes of a 1*4 board",
+			1,
+			4,
+			[]Position{{0, 1}, {0, 2}, {0, 0}, {0, 3}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := OrderByCentre(test.rows, test.cols); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("unexpected order = %v, want %v", got, test.want)
 			}
 
 		})
@@ -450,6 +1133,46 @@ func TestCheckForWinner(t *testing.T) {
 				false,
 			},
 		},
+		{
+			"returns true when board reflects a win via a column on a 4*2 dimension",
+			args{
+				O,
+				3,
+				1,
+			},
+			fields{
+				4,
+				[][]BoxContent{
+					{X, O},
+					{X, O},
+					{X, O},
+					{E, O},
+				},
+				winConditionChecks,
+			},
+			want{
+				true,
+			},
+		},
+		{
+			"returns false when board does not reflect a win via a row on a 2*5 dimension",
+			args{
+				X,
+				1,
+				4,
+			},
+			fields{
+				4,
+				[][]BoxContent{
+					{O, O, O, E, E},
+					{E, E, X, X, X},
+				},
+				winConditionChecks,
+			},
+			want{
+				false,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -476,3 +1199,140 @@ func TestCheckForWinner(t *testing.T) {
 	}
 
 }
+
+func TestFindWinningLine(t *testing.T) {
+
+	type args struct {
+		playerSymbol BoxContent
+		rowIdx       int
+		colIdx       int
+	}
+
+	type want struct {
+		expectWin  bool
+		expectLine WinningLine
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		boxes [][]BoxContent
+		want  want
+	}{
+		{
+			"returns the row from left to right when the middle box completes it",
+			args{
+				X,
+				1,
+				1,
+			},
+			[][]BoxContent{
+				{E, E, E},
+				{X, X, X},
+				{E, E, E},
+			},
+			want{
+				true,
+				WinningLine{Row, []Position{{1, 0}, {1, 1}, {1, 2}}},
+			},
+		},
+		{
+			"returns the column from top to bottom",
+			args{
+				O,
+				2,
+				0,
+			},
+			[][]BoxContent{
+				{O, E, E},
+				{O, E, E},
+				{O, E, E},
+			},
+			want{
+				true,
+				WinningLine{Column, []Position{{0, 0}, {1, 0}, {2, 0}}},
+			},
+		},
+		{
+			"returns the diagonal from the top left",
+			args{
+				X,
+				0,
+				0,
+			},
+			[][]BoxContent{
+				{X, E, E},
+				{E, X, E},
+				{E, E, X},
+			},
+			want{
+				true,
+				WinningLine{Diagonal, []Position{{0, 0}, {1, 1}, {2, 2}}},
+			},
+		},
+		{
+			"returns the whole anti-diagonal run on a 5*5 dimension when it is longer than needed",
+			args{
+				X,
+				2,
+				2,
+			},
+			[][]BoxContent{
+				{E, E, E, E, E},
+				{E, E, E, X, E},
+				{E, E, X, E, E},
+				{E, X, E, E, E},
+				{X, E, E, E, E},
+			},
+			want{
+				true,
+				WinningLine{AntiDiagonal, []Position{{1, 3}, {2, 2}, {3, 1}, {4, 0}}},
+			},
+		},
+		{
+			"returns no line when the board does not reflect a win",
+			args{
+				X,
+				0,
+				0,
+			},
+			[][]BoxContent{
+				{X, X, O},
+				{E, E, E},
+				{E, E, E},
+			},
+			want{
+				false,
+				WinningLine{},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testBoard := Board{
+				WinCount:           3,
+				Boxes:              test.boxes,
+				WinConditionChecks: winConditionChecks,
+			}
+
+			checkForWinnerParams := CheckForWinnerParams{
+				PlayerSymbol: test.args.playerSymbol,
+				RowIdx:       test.args.rowIdx,
+				ColIdx:       test.args.colIdx,
+			}
+
+			gotLine, gotWin := testBoard.FindWinningLine(checkForWinnerParams)
+
+			if gotWin != test.want.expectWin {
+				t.Errorf("unexpected check result = %t, want %t", gotWin, test.want.expectWin)
+			}
+
+			if !reflect.DeepEqual(gotLine, test.want.expectLine) {
+				t.Errorf("unexpected WinningLine = %v, want %v", gotLine, test.want.expectLine)
+			}
+
+		})
+	}
+
+}
@@ -0,0 +1,155 @@
+package board
+
+// MaxEvaluation is the score Evaluate gives a board won by the player, Evaluate never scores a board above it or below -MaxEvaluation
+// so that a search can keep the scores of positions it has seen won above every evaluation
+const MaxEvaluation = 1 << 16
+
+// lineWeight returns the weight of an open line holding a number of boxes filled by one player, each box more is worth 4 times as much
+func lineWeight(filled int) int {
+	return 1 << (2 * (filled - 1))
+}
+
+// Evaluate scores the board for the player with the symbol without searching ahead, the higher the score the better the board is for the player
+// it allocates the memory it scores with on every call, a search scoring many boards uses an Evaluator instead
+func (b *Board) Evaluate(symbol BoxContent) int {
+	var e Evaluator
+	return e.Evaluate(b, symbol)
+}
+
+// Evaluator scores boards the same way as Board.Evaluate, keeping the memory it scores with between calls so that scoring a board does not allocate
+// the zero value is ready to use, an evaluator is not safe for concurrent use so every goroutine searching needs its own
+type Evaluator struct {
+	// every box keeps a bit for each symbol it is a threat for, and four bits for each symbol with the directions of the lines it would make a threat along
+	threats        []uint8
+	forkDirections []uint16
+}
+
+// Evaluate scores the board for the player with the symbol, see Board.Evaluate
+func (e *Evaluator) Evaluate(b *Board, symbol BoxContent) int {
+	var scores [S + 1]int
+
+	boxes := b.Rows() * b.Cols()
+	if cap(e.threats) < boxes {
+		e.threats = make([]uint8, boxes)
+		e.forkDirections = make([]uint16, boxes)
+	}
+	threats := e.threats[:boxes]
+	forkDirections := e.forkDirections[:boxes]
+	for box := range threats {
+		threats[box] = 0
+		forkDirections[box] = 0
+	}
+
+	// every run of WinCount boxes along the directions of WinConditionChecks that holds a single symbol is an open line for that symbol
+	for row := range b.Boxes {
+		for col := range b.Boxes[row] {
+			for i, winConditionCheck := range b.WinConditionChecks {
+				c := winConditionCheck.checks[0]
+
+				lineSymbol, filled, open := b.openRun(row, col, c)
+				if !open || lineSymbol == E {
+					continue
+				}
+
+				// a completed line ends the game however many players there are, under misere rules every player but the one who completed it shares the win
+				if filled == b.WinCount {
+					if (lineSymbol == symbol) != b.Misere {
+						return MaxEvaluation
+					}
+					return -MaxEvaluation
+				}
+
+				// open lines score more the more boxes they have filled
+				scores[lineSymbol] += lineWeight(filled)
+
+				// a line with one box left is a threat, a line with two left turns into a threat when one of them is filled
+				if filled != b.WinCount-1 && filled != b.WinCount-2 {
+					continue
+				}
+
+				for j := 0; j < b.WinCount; j++ {
+					rowIdx := row + (int(c.rowDirection) * j)
+					colIdx := col + (int(c.colDirection) * j)
+					if b.Boxes[rowIdx][colIdx] != E {
+						continue
+					}
+
+					if filled == b.WinCount-1 {
+						threats[rowIdx*b.Cols()+colIdx] |= 1 << lineSymbol
+					} else {
+						forkDirections[rowIdx*b.Cols()+colIdx] |= 1 << (4*(lineSymbol-X) + BoxContent(i))
+					}
+				}
+			}
+		}
+	}
+
+	// on top of its lines a player scores for two or more boxes that would each complete a line, which is a double threat,
+	// and for every box that would give them two threats at once, which is a fork
+	for s := X; s <= S; s++ {
+		threatCount := 0
+		for box := range threats {
+			if threats[box]&(1<<s) != 0 {
+				threatCount++
+			}
+
+			// a box shared by lines in different directions makes a threat along each of them when it is filled
+			directions := (forkDirections[box] >> (4 * (s - X))) & 0xf
+			if directions&(directions-1) != 0 {
+				scores[s] += lineWeight(b.WinCount - 1)
+			}
+		}
+
+		if threatCount >= 2 {
+			scores[s] += 2 * lineWeight(b.WinCount)
+		}
+	}
+
+	// lines of the other players count against the player, and under misere rules the whole score is turned around
+	score := 0
+	for s := X; s <= S; s++ {
+		if s == symbol {
+			score += scores[s]
+		} else {
+			score -= scores[s]
+		}
+	}
+
+	if b.Misere {
+		score = -score
+	}
+
+	if score > MaxEvaluation-1 {
+		return MaxEvaluation - 1
+	} else if score < -(MaxEvaluation - 1) {
+		return -(MaxEvaluation - 1)
+	}
+
+	return score
+}
+
+// openRun returns the symbol filling the run of WinCount boxes starting from the box on a particular row and col idx along a check path
+// and how many boxes it fills, the run is open when it is on the board and holds no more than one symbol
+func (b *Board) openRun(rowIdx, colIdx int, c check) (BoxContent, int, bool) {
+	endRowIdx := rowIdx + (int(c.rowDirection) * (b.WinCount - 1))
+	endColIdx := colIdx + (int(c.colDirection) * (b.WinCount - 1))
+	if !b.isWithinBounds(endRowIdx, endColIdx) {
+		return E, 0, false
+	}
+
+	symbol := E
+	filled := 0
+	for i := 0; i < b.WinCount; i++ {
+		content := b.Boxes[rowIdx+(int(c.rowDirection)*i)][colIdx+(int(c.colDirection)*i)]
+		if content == E {
+			continue
+		} else if symbol != E && content != symbol {
+			return E, 0, false
+		}
+
+		symbol = content
+		filled++
+	}
+
+	return symbol, filled, true
+}
@@ -0,0 +1,131 @@
+package board
+
+import "testing"
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name     string
+		position string
+		symbol   BoxContent
+		want     int
+	}{
+		{
+			"returns 0 on an empty board",
+			"3x3 3 3/3/3 x",
+			X,
+			0,
+		},
+		{
+			"scores every open line through the centre",
+			"3x3 3 3/1x1/3 o",
+			X,
+			4,
+		},
+		{
+			"scores the lines of the opponent against the player",
+			"3x3 3 3/1x1/3 o",
+			O,
+			-4,
+		},
+		{
+			"leaves out lines blocked by the other symbol",
+			"3x3 3 x2/1o1/3 x",
+			X,
+			-1,
+		},
+		{
+			"scores a line with one box left more than lines with one box filled",
+			"3x3 3 x1x/1o1/3 o",
+			X,
+			4,
+		},
+		{
+			"scores the boxes that would make two threats at once as forks",
+			"3x3 3 x2/1o1/2x o",
+			X,
+			9,
+		},
+		{
+			"scores a double threat",
+			"3x3 3 xx1/x2/1oo o",
+			X,
+			36,
+		},
+		{
+			"returns 0 when no line can be completed",
+			"3x3 3 xox/xoo/ox1 x",
+			X,
+			0,
+		},
+		{
+			"returns the highest score when the player has completed a line",
+			"3x3 3 xxx/oo1/3 -",
+			X,
+			MaxEvaluation,
+		},
+		{
+			"returns the lowest score when the opponent has completed a line",
+			"3x3 3 xxx/oo1/3 -",
+			O,
+			-MaxEvaluation,
+		},
+		{
+			"turns the score around under misere rules",
+			"3x3 3 3/1x1/3 o m",
+			X,
+			-4,
+		},
+		{
+			"returns the lowest score when the player has completed a line under misere rules",
+			"3x3 3 xxx/oo1/3 - m",
+			X,
+			-MaxEvaluation,
+		},
+		{
+			"returns the highest score when another of three players has completed a line under misere rules",
+			"4x4 3 ooo1/xx2/tt2/4 - m",
+			X,
+			MaxEvaluation,
+		},
+		{
+			"returns the lowest score when the player has completed a line with three players under misere rules",
+			"4x4 3 ooo1/xx2/tt2/4 - m",
+			O,
+			-MaxEvaluation,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, _, err := ParsePosition(test.position)
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			if got := b.Evaluate(test.symbol); got != test.want {
+				t.Errorf("unexpected evaluation = %d, want %d", got, test.want)
+			}
+
+		})
+	}
+
+}
+
+func TestEvaluatorAllocations(t *testing.T) {
+	b, _, err := ParsePosition("7x7 4 7/7/2x4/2oxo2/3x3/7/7 o")
+	if err != nil {
+		t.Fatalf("unexpected error = %v, want %v", err, nil)
+	}
+
+	// the evaluator keeps its memory between calls, so only the first board it scores allocates
+	var e Evaluator
+	want := b.Evaluate(X)
+	if got := e.Evaluate(b, X); got != want {
+		t.Errorf("unexpected evaluation = %d, want %d", got, want)
+	}
+
+	if allocs := testing.AllocsPerRun(10, func() { e.Evaluate(b, X) }); allocs != 0 {
+		t.Errorf("unexpected allocations = %v, want %v", allocs, 0)
+	}
+
+}
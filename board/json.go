@@ -0,0 +1,101 @@
+package board
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+var (
+	ErrInvalidMove = errors.New("move does not match the content of its box")
+)
+
+// boardJSON is the JSON representation of a board, the moves are the history of the board in the order they were made
+type boardJSON struct {
+	Rows     int            `json:"rows"`
+	Cols     int            `json:"cols"`
+	WinCount int            `json:"winCount"`
+	Gravity  bool           `json:"gravity"`
+	Misere   bool           `json:"misere"`
+	Boxes    [][]BoxContent `json:"boxes"`
+	Moves    []Move         `json:"moves"`
+}
+
+// MarshalText returns the name of the box content
+func (c BoxContent) MarshalText() ([]byte, error) {
+	format, ok := BoxContentFormats[c]
+	if !ok {
+		return nil, ErrInvalidContent
+	}
+
+	return []byte(format.Name), nil
+}
+
+// UnmarshalText sets the box content from its name
+func (c *BoxContent) UnmarshalText(text []byte) error {
+	for content, format := range BoxContentFormats {
+		if format.Name == string(text) {
+			*c = content
+			return nil
+		}
+	}
+
+	return ErrInvalidContent
+}
+
+// MarshalJSON returns the JSON representation of the board with its dimensions, rules, boxes and the moves made on it
+// moves that have been undone are not kept
+func (b *Board) MarshalJSON() ([]byte, error) {
+	return json.Marshal(boardJSON{
+		Rows:     b.Rows(),
+		Cols:     b.Cols(),
+		WinCount: b.WinCount,
+		Gravity:  b.Gravity,
+		Misere:   b.Misere,
+		Boxes:    b.Boxes,
+		Moves:    b.History(),
+	})
+}
+
+// UnmarshalJSON sets the board from its JSON representation
+// the board is validated so that every box is on the board and holds a known content, and every move is on a box holding the move's symbol
+func (b *Board) UnmarshalJSON(data []byte) error {
+	var bj boardJSON
+	if err := json.Unmarshal(data, &bj); err != nil {
+		return err
+	}
+
+	newBoardWithBoxesParams := NewBoardWithBoxesParams{
+		WinCount: bj.WinCount,
+		Rows:     bj.Rows,
+		Cols:     bj.Cols,
+		Gravity:  bj.Gravity,
+		Misere:   bj.Misere,
+		Boxes:    bj.Boxes,
+	}
+
+	newBoard, err := NewBoardWithBoxes(newBoardWithBoxesParams)
+	if err != nil {
+		return err
+	}
+
+	// a box can only have been filled by one move
+	moved := make(map[Position]bool, len(bj.Moves))
+	for _, move := range bj.Moves {
+		position := Position{move.RowIdx, move.ColIdx}
+
+		if !newBoard.isWithinBounds(move.RowIdx, move.ColIdx) {
+			return ErrOutOfBounds
+		} else if !move.Content.isPlayerSymbol() {
+			return ErrInvalidContent
+		} else if newBoard.Boxes[move.RowIdx][move.ColIdx] != move.Content || moved[position] {
+			return ErrInvalidMove
+		}
+
+		moved[position] = true
+	}
+
+	newBoard.history = bj.Moves
+	*b = *newBoard
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package board
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	b, _ := NewBoard(NewBoardParams{WinCount: 3, Rows: 2, Cols: 3, Gravity: true})
+	b.DropPiece(DropPieceParams{ColIdx: 0, Content: X})
+	b.DropPiece(DropPieceParams{ColIdx: 2, Content: T})
+
+	got, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("unexpected error = %v, want %v", err, nil)
+	}
+
+	want := `{"rows":2,"cols":3,"winCount":3,"gravity":true,"misere":false,` +
+		`"boxes":[["","",""],["x","","triangle"]],` +
+		`"moves":[{"row":1,"col":0,"symbol":"x"},{"row":1,"col":2,"symbol":"triangle"}]}`
+	if string(got) != want {
+		t.Errorf("unexpected JSON = %s, want %s", got, want)
+	}
+
+	var unmarshalled Board
+	if err := json.Unmarshal(got, &unmarshalled); err != nil {
+		t.Fatalf("unexpected error = %v, want %v", err, nil)
+	}
+
+	if !reflect.DeepEqual(&unmarshalled, b) {
+		t.Errorf("unexpected board = %v, want %v", unmarshalled, *b)
+	}
+
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	type want struct {
+		err     error
+		boxes   [][]BoxContent
+		history []Move
+	}
+
+	tests := []struct {
+		name string
+		data string
+		want want
+	}{
+		{
+			"unmarshals a board with its moves",
+			`{"rows":2,"cols":2,"winCount":2,"boxes":[["x",""],["","o"]],"moves":[{"row":0,"col":0,"symbol":"x"},{"row":1,"col":1,"symbol":"o"}]}`,
+			want{
+				nil,
+				[][]BoxContent{{X, E}, {E, O}},
+				[]Move{{0, 0, X}, {1, 1, O}},
+			},
+		},
+		{
+			"returns error when the win count cannot be reached",
+			`{"rows":2,"cols":2,"winCount":3,"boxes":[["",""],["",""]]}`,
+			want{
+				ErrUnreachableWinCount,
+				nil,
+				nil,
+			},
+		},
+		{
+			"returns error when a row is missing",
+			`{"rows":2,"cols":2,"winCount":2,"boxes":[["",""]]}`,
+			want{
+				ErrInvalidBoxes,
+				nil,
+				nil,
+			},
+		},
+		{
+			"returns error without allocating the board when the dimensions are far bigger than the boxes",
+			`{"rows":1000000,"cols":1000000,"winCount":3,"boxes":[]}`,
+			want{
+				ErrInvalidBoxes,
+				nil,
+				nil,
+			},
+		},
+		{
+			"returns error when a row is too long",
+			`{"rows":2,"cols":2,"winCount":2,"boxes":[["",""],["","",""]]}`,
+			want{
+				ErrInvalidBoxes,
+				nil,
+				nil,
+			},
+		},
+		{
+			"returns error when a box holds an unknown symbol",
+			`{"rows":2,"cols":2,"winCount":2,"boxes":[["",""],["","star"]]}`,
+			want{
+				ErrInvalidContent,
+				nil,
+				nil,
+			},
+		},
+		{
+			"returns error when a move is outside of the board",
+			`{"rows":2,"cols":2,"winCount":2,"boxes":[["",""],["",""]],"moves":[{"row":2,"col":0,"symbol":"x"}]}`,
+			want{
+				ErrOutOfBounds,
+				nil,
+				nil,
+			},
+		},
+		{
+			"returns error when a move does not match its box",
+			`{"rows":2,"cols":2,"winCount":2,"boxes":[["x",""],["",""]],"moves":[{"row":0,"col":0,"symbol":"o"}]}`,
+			want{
+				ErrInvalidMove,
+				nil,
+				nil,
+			},
+		},
+		{
+			"returns error when a box is filled by two moves",
+			`{"rows":2,"cols":2,"winCount":2,"boxes":[["x",""],["",""]],"moves":[{"row":0,"col":0,"symbol":"x"},{"row":0,"col":0,"symbol":"x"}]}`,
+			want{
+				ErrInvalidMove,
+				nil,
+				nil,
+			},
+		},
+		{
+			"returns error when a move is not made with a player's symbol",
+			`{"rows":2,"cols":2,"winCount":2,"boxes":[["",""],["",""]],"moves":[{"row":0,"col":0,"symbol":""}]}`,
+			want{
+				ErrInvalidContent,
+				nil,
+				nil,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var b Board
+			err := json.Unmarshal([]byte(test.data), &b)
+
+			if !errors.Is(err, test.want.err) {
+				t.Errorf("unexpected error = %v, want %v", err, test.want.err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(b.Boxes, test.want.boxes) {
+				t.Errorf("unexpected Boxes = %v, want %v", b.Boxes, test.want.boxes)
+			}
+
+			if !reflect.DeepEqual(b.History(), test.want.history) {
+				t.Errorf("unexpected history = %v, want %v", b.History(), test.want.history)
+			}
+
+		})
+	}
+
+}
@@ -0,0 +1,295 @@
+package board
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// A position string describes a board on a single line, much like FEN does for chess, with space separated fields:
+//
+//	<rows>x<cols> <win count> <boxes> <player to move> [rules]
+//
+// boxes are the rows of the board from top to bottom separated by /, each box is the character of its symbol
+// and a run of empty boxes is written as the number of boxes in the run. The player to move is the character of their symbol,
+// or - when nobody is to move because the game is over
+// and the optional rules are g for gravity and m for misere, e.g. "3x3 3 x1o/1x1/3 o" or "6x7 4 7/7/7/7/7/3x3 o g".
+//
+// Moves are written with a1 style coordinates, the column letter followed by the row number, counting from a1 at the top left box.
+// Columns after z continue with aa, ab and so on, and a move record is the coordinates of its moves separated by spaces.
+
+var (
+	ErrInvalidPosition   = errors.New("position string is invalid")
+	ErrInvalidCoordinate = errors.New("coordinate must be a column letter followed by a row number, such as a1")
+)
+
+// noPlayerToMove is written in place of the player to move when the game is over
+const noPlayerToMove = "-"
+
+// ParseMovesParams defines the structure for the parameters needed to parse a move record
+type ParseMovesParams struct {
+	Moves string
+	// Symbols are the symbols of the players in the order they take turns, starting with the player making the first move
+	Symbols []BoxContent
+}
+
+// ParsePosition creates the board described by a position string and returns it with the symbol of the player to move
+// the board has no move history, and with gravity every symbol must rest on the bottom row or on another symbol
+func ParsePosition(position string) (*Board, BoxContent, error) {
+	fields := strings.Fields(position)
+	if len(fields) != 4 && len(fields) != 5 {
+		return nil, E, ErrInvalidPosition
+	}
+
+	dimensions := strings.SplitN(fields[0], "x", 2)
+	if len(dimensions) != 2 {
+		return nil, E, ErrInvalidPosition
+	}
+
+	rows, rowsErr := strconv.Atoi(dimensions[0])
+	cols, colsErr := strconv.Atoi(dimensions[1])
+	winCount, winCountErr := strconv.Atoi(fields[1])
+	if rowsErr != nil || colsErr != nil || winCountErr != nil {
+		return nil, E, ErrInvalidPosition
+	}
+
+	if err := checkBoardParams(NewBoardParams{WinCount: winCount, Rows: rows, Cols: cols}); err != nil {
+		return nil, E, err
+	}
+
+	newBoardWithBoxesParams := NewBoardWithBoxesParams{
+		WinCount: winCount,
+		Rows:     rows,
+		Cols:     cols,
+	}
+
+	if len(fields) == 5 {
+		for _, rule := range fields[4] {
+			switch rule {
+			case 'g':
+				newBoardWithBoxesParams.Gravity = true
+			case 'm':
+				newBoardWithBoxesParams.Misere = true
+			default:
+				return nil, E, ErrInvalidPosition
+			}
+		}
+	}
+
+	// every row is checked to hold cols boxes before the boxes are allocated, so that a pasted string cannot claim a board bigger than it describes
+	boxRows := strings.Split(fields[2], "/")
+	if len(boxRows) != rows {
+		return nil, E, ErrInvalidPosition
+	}
+
+	var filled []Move
+	for row := range boxRows {
+		moves, err := parseBoxRow(boxRows[row], row, cols)
+		if err != nil {
+			return nil, E, err
+		}
+		filled = append(filled, moves...)
+	}
+
+	newBoardWithBoxesParams.Boxes = make([][]BoxContent, rows)
+	for row := range newBoardWithBoxesParams.Boxes {
+		newBoardWithBoxesParams.Boxes[row] = make([]BoxContent, cols)
+	}
+	for _, move := range filled {
+		newBoardWithBoxesParams.Boxes[move.RowIdx][move.ColIdx] = move.Content
+	}
+
+	b, err := NewBoardWithBoxes(newBoardWithBoxesParams)
+	if err != nil {
+		return nil, E, err
+	}
+
+	// with gravity a symbol can only rest on the bottom row or on top of another symbol
+	if b.Gravity {
+		for row := 0; row < rows-1; row++ {
+			for col := 0; col < cols; col++ {
+				if b.Boxes[row][col] != E && b.Boxes[row+1][col] == E {
+					return nil, E, ErrBoxNotSupported
+				}
+			}
+		}
+	}
+
+	if len(fields[3]) != 1 {
+		return nil, E, ErrInvalidPosition
+	}
+
+	if fields[3] == noPlayerToMove {
+		return b, E, nil
+	}
+
+	toMove, ok := SymbolFromCharacter(fields[3][0])
+	if !ok {
+		return nil, E, ErrInvalidPosition
+	}
+
+	return b, toMove, nil
+}
+
+// FormatPosition returns the position string of the board with the symbol of the player to move, E when nobody is to move
+func (b *Board) FormatPosition(toMove BoxContent) string {
+	boxRows := make([]string, 0, b.Rows())
+	for row := range b.Boxes {
+		var sb strings.Builder
+		emptyRun := 0
+
+		for col := range b.Boxes[row] {
+			if b.Boxes[row][col] == E {
+				emptyRun++
+				continue
+			}
+
+			if emptyRun > 0 {
+				sb.WriteString(strconv.Itoa(emptyRun))
+				emptyRun = 0
+			}
+			sb.WriteByte(BoxContentFormats[b.Boxes[row][col]].Character)
+		}
+
+		if emptyRun > 0 {
+			sb.WriteString(strconv.Itoa(emptyRun))
+		}
+		boxRows = append(boxRows, sb.String())
+	}
+
+	fields := []string{
+		strconv.Itoa(b.Rows()) + "x" + strconv.Itoa(b.Cols()),
+		strconv.Itoa(b.WinCount),
+		strings.Join(boxRows, "/"),
+		noPlayerToMove,
+	}
+	if toMove != E {
+		fields[3] = string(BoxContentFormats[toMove].Character)
+	}
+
+	var rules string
+	if b.Gravity {
+		rules += "g"
+	}
+	if b.Misere {
+		rules += "m"
+	}
+	if rules != "" {
+		fields = append(fields, rules)
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// ParseCoordinate returns the row and col idx of the box at an a1 style coordinate
+// the coordinate is not checked against the dimensions of a board, filling a box outside of the board fails with ErrOutOfBounds
+func ParseCoordinate(coordinate string) (Position, error) {
+	coordinate = strings.ToLower(coordinate)
+
+	letters := 0
+	for letters < len(coordinate) && coordinate[letters] >= 'a' && coordinate[letters] <= 'z' {
+		letters++
+	}
+
+	if letters == 0 {
+		return Position{}, ErrInvalidCoordinate
+	}
+
+	rowNumber, err := strconv.Atoi(coordinate[letters:])
+	if err != nil || rowNumber < 1 {
+		return Position{}, ErrInvalidCoordinate
+	}
+
+	// columns are numbered like spreadsheet columns, a to z and then aa, ab and so on
+	colNumber := 0
+	for i := 0; i < letters; i++ {
+		colNumber = colNumber*26 + int(coordinate[i]-'a') + 1
+	}
+
+	return Position{rowNumber - 1, colNumber - 1}, nil
+}
+
+// FormatCoordinate returns the a1 style coordinate of a box
+func FormatCoordinate(p Position) string {
+	var letters []byte
+	for colNumber := p.ColIdx + 1; colNumber > 0; colNumber = (colNumber - 1) / 26 {
+		letters = append([]byte{byte('a' + (colNumber-1)%26)}, letters...)
+	}
+
+	return string(letters) + strconv.Itoa(p.RowIdx+1)
+}
+
+// ParseMoves returns the moves of a move record, the symbols are handed to the moves in turn order
+func ParseMoves(p ParseMovesParams) ([]Move, error) {
+	if len(p.Symbols) == 0 {
+		return nil, ErrInvalidContent
+	}
+
+	coordinates := strings.Fields(p.Moves)
+	moves := make([]Move, 0, len(coordinates))
+
+	for i, coordinate := range coordinates {
+		position, err := ParseCoordinate(coordinate)
+		if err != nil {
+			return nil, err
+		}
+
+		moves = append(moves, Move{position.RowIdx, position.ColIdx, p.Symbols[i%len(p.Symbols)]})
+	}
+
+	return moves, nil
+}
+
+// FormatMoves returns the move record of the moves, the coordinates of the boxes filled in the order the moves were made
+func FormatMoves(moves []Move) string {
+	coordinates := make([]string, 0, len(moves))
+	for _, move := range moves {
+		coordinates = append(coordinates, FormatCoordinate(Position{move.RowIdx, move.ColIdx}))
+	}
+
+	return strings.Join(coordinates, " ")
+}
+
+// parseBoxRow returns the boxes filled in the row with the row idx of a position string, as the moves filling them
+// the row must hold exactly cols boxes, runs of empty boxes are counted without being allocated
+func parseBoxRow(boxRow string, rowIdx, cols int) ([]Move, error) {
+	var moves []Move
+	col := 0
+
+	for i := 0; i < len(boxRow); i++ {
+		if isDigit(boxRow[i]) {
+			end := i + 1
+			for end < len(boxRow) && isDigit(boxRow[end]) {
+				end++
+			}
+
+			emptyRun, err := strconv.Atoi(boxRow[i:end])
+			if err != nil || boxRow[i] == '0' || emptyRun > cols-col {
+				return nil, ErrInvalidPosition
+			}
+
+			col += emptyRun
+			i = end - 1
+			continue
+		}
+
+		content, ok := SymbolFromCharacter(boxRow[i])
+		if !ok || col >= cols {
+			return nil, ErrInvalidPosition
+		}
+
+		moves = append(moves, Move{rowIdx, col, content})
+		col++
+	}
+
+	if col != cols {
+		return nil, ErrInvalidPosition
+	}
+
+	return moves, nil
+}
+
+// isDigit checks if the character is a decimal digit
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
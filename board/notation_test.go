@@ -0,0 +1,330 @@
+package board
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePosition(t *testing.T) {
+	type want struct {
+		err      error
+		winCount int
+		gravity  bool
+		misere   bool
+		boxes    [][]BoxContent
+		toMove   BoxContent
+	}
+
+	tests := []struct {
+		name     string
+		position string
+		want     want
+	}{
+		{
+			"parses a square board",
+			"3x3 3 x1o/1x1/3 o",
+			want{
+				nil,
+				3,
+				false,
+				false,
+				[][]BoxContent{
+					{X, E, O},
+					{E, X, E},
+					{E, E, E},
+				},
+				O,
+			},
+		},
+		{
+			"returns error when the rules are split over two fields",
+			"3x4 3 4/1t2/xost g m",
+			want{
+				ErrInvalidPosition,
+				0,
+				false,
+				false,
+				nil,
+				E,
+			},
+		},
+		{
+			"parses a board with rules",
+			"3x4 3 4/1t2/xost s gm",
+			want{
+				nil,
+				3,
+				true,
+				true,
+				[][]BoxContent{
+					{E, E, E, E},
+					{E, T, E, E},
+					{X, O, S, T},
+				},
+				S,
+			},
+		},
+		{
+			"parses a run of more than 9 empty boxes",
+			"2x12 5 12/x10o -",
+			want{
+				nil,
+				5,
+				false,
+				false,
+				[][]BoxContent{
+					{E, E, E, E, E, E, E, E, E, E, E, E},
+					{X, E, E, E, E, E, E, E, E, E, E, O},
+				},
+				E,
+			},
+		},
+		{
+			"returns error when a row is too short",
+			"3x3 3 x1o/1x/3 o",
+			want{
+				ErrInvalidPosition,
+				0,
+				false,
+				false,
+				nil,
+				E,
+			},
+		},
+		{
+			"returns error when a row is too long",
+			"3x3 3 x2o/1x1/3 o",
+			want{
+				ErrInvalidPosition,
+				0,
+				false,
+				false,
+				nil,
+				E,
+			},
+		},
+		{
+			"returns error when a row is missing",
+			"3x3 3 x1o/1x1 o",
+			want{
+				ErrInvalidPosition,
+				0,
+				false,
+				false,
+				nil,
+				E,
+			},
+		},
+		{
+			"returns error without allocating the board when the dimensions are far bigger than the rows given",
+			"1000000x1000000 3 1 x",
+			want{
+				ErrInvalidPosition,
+				0,
+				false,
+				false,
+				nil,
+				E,
+			},
+		},
+		{
+			"returns error without allocating the board when a row is far shorter than the cols",
+			"2x1000000000 3 1000000000/1 x",
+			want{
+				ErrInvalidPosition,
+				0,
+				false,
+				false,
+				nil,
+				E,
+			},
+		},
+		{
+			"returns error when a box holds an unknown symbol",
+			"3x3 3 x1q/1x1/3 o",
+			want{
+				ErrInvalidPosition,
+				0,
+				false,
+				false,
+				nil,
+				E,
+			},
+		},
+		{
+			"returns error when the player to move is unknown",
+			"3x3 3 x1o/1x1/3 q",
+			want{
+				ErrInvalidPosition,
+				0,
+				false,
+				false,
+				nil,
+				E,
+			},
+		},
+		{
+			"returns error when a symbol floats above an empty box with gravity",
+			"3x3 3 x2/3/3 o g",
+			want{
+				ErrBoxNotSupported,
+				0,
+				false,
+				false,
+				nil,
+				E,
+			},
+		},
+		{
+			"returns error when the win count cannot be reached",
+			"3x3 4 3/3/3 x",
+			want{
+				ErrUnreachableWinCount,
+				0,
+				false,
+				false,
+				nil,
+				E,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, toMove, err := ParsePosition(test.position)
+
+			if !reflect.DeepEqual(err, test.want.err) {
+				t.Errorf("unexpected error = %v, want %v", err, test.want.err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if b.WinCount != test.want.winCount || b.Gravity != test.want.gravity || b.Misere != test.want.misere {
+				t.Errorf("unexpected rules = %d %t %t, want %d %t %t", b.WinCount, b.Gravity, b.Misere, test.want.winCount, test.want.gravity, test.want.misere)
+			}
+
+			if !reflect.DeepEqual(b.Boxes, test.want.boxes) {
+				t.Errorf("unexpected Boxes = %v, want %v", b.Boxes, test.want.boxes)
+			}
+
+			if toMove != test.want.toMove {
+				t.Errorf("unexpected player to move = %v, want %v", toMove, test.want.toMove)
+			}
+
+			if gotPosition := b.FormatPosition(toMove); gotPosition != test.position {
+				t.Errorf("unexpected position = %s, want %s", gotPosition, test.position)
+			}
+
+		})
+	}
+
+}
+
+func TestCoordinate(t *testing.T) {
+	type want struct {
+		err      error
+		position Position
+	}
+
+	tests := []struct {
+		name       string
+		coordinate string
+		want       want
+	}{
+		{
+			"parses the top left box",
+			"a1",
+			want{
+				nil,
+				Position{0, 0},
+			},
+		},
+		{
+			"parses a box on a row with more than one digit",
+			"c12",
+			want{
+				nil,
+				Position{11, 2},
+			},
+		},
+		{
+			"parses a column after z",
+			"ab3",
+			want{
+				nil,
+				Position{2, 27},
+			},
+		},
+		{
+			"returns error when the column is missing",
+			"12",
+			want{
+				ErrInvalidCoordinate,
+				Position{},
+			},
+		},
+		{
+			"returns error when the row is missing",
+			"b",
+			want{
+				ErrInvalidCoordinate,
+				Position{},
+			},
+		},
+		{
+			"returns error when the row is 0",
+			"b0",
+			want{
+				ErrInvalidCoordinate,
+				Position{},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			position, err := ParseCoordinate(test.coordinate)
+
+			if !reflect.DeepEqual(err, test.want.err) {
+				t.Errorf("unexpected error = %v, want %v", err, test.want.err)
+			}
+
+			if position != test.want.position {
+				t.Errorf("unexpected position = %v, want %v", position, test.want.position)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if gotCoordinate := FormatCoordinate(position); gotCoordinate != test.coordinate {
+				t.Errorf("unexpected coordinate = %s, want %s", gotCoordinate, test.coordinate)
+			}
+
+		})
+	}
+
+}
+
+func TestParseMoves(t *testing.T) {
+	moves, err := ParseMoves(ParseMovesParams{Moves: "b2 a1 c3 z1", Symbols: []BoxContent{X, O, T}})
+	if err != nil {
+		t.Fatalf("unexpected error = %v, want %v", err, nil)
+	}
+
+	wantMoves := []Move{{1, 1, X}, {0, 0, O}, {2, 2, T}, {0, 25, X}}
+	if !reflect.DeepEqual(moves, wantMoves) {
+		t.Errorf("unexpected moves = %v, want %v", moves, wantMoves)
+	}
+
+	if record := FormatMoves(moves); record != "b2 a1 c3 z1" {
+		t.Errorf("unexpected move record = %s, want %s", record, "b2 a1 c3 z1")
+	}
+
+	if _, err := ParseMoves(ParseMovesParams{Moves: "b2 2b", Symbols: []BoxContent{X, O}}); err != ErrInvalidCoordinate {
+		t.Errorf("unexpected error = %v, want %v", err, ErrInvalidCoordinate)
+	}
+
+}
@@ -0,0 +1,224 @@
+package board
+
+import (
+	"errors"
+)
+
+var (
+	ErrMultipleWinners   = errors.New("more than one symbol has completed a line")
+	ErrSeparateLines     = errors.New("lines completed by a symbol do not all go through the box of its last move")
+	ErrImpossibleCounts  = errors.New("symbols on the board cannot have been placed by players taking turns")
+	ErrWrongPlayerToMove = errors.New("player to move cannot be next after the symbols on the board")
+)
+
+// State is the state of a board worked out from all of its boxes
+type State int
+
+// States of a board
+const (
+	InProgress State = iota // No line is completed and boxes are left to fill
+	Won                     // A symbol has completed a line
+	Lost                    // A symbol has completed a line under misere rules
+	Draw                    // No line is completed and none can be anymore
+	Invalid                 // Board cannot be reached by players taking turns
+)
+
+// String returns the readable name of a board state
+func (s State) String() string {
+	switch s {
+	case InProgress:
+		return "in progress"
+	case Won:
+		return "won"
+	case Lost:
+		return "lost"
+	case Draw:
+		return "draw"
+	case Invalid:
+		return "invalid"
+	default:
+		return "unknown"
+	}
+}
+
+// Status is the state of a board along with the symbol and line that has decided it
+type Status struct {
+	State State
+	// Symbol is the symbol that has completed a line when the board is won or lost
+	Symbol BoxContent
+	// WinningLine is one of the lines completed by Symbol
+	WinningLine WinningLine
+	// Reason explains why the board is invalid
+	Reason error
+}
+
+// Status scans every box of the board for runs of WinCount boxes filled with the same symbol and returns the state of the board
+// players are assumed to take turns in the order of PlayerSymbols, starting with any of them as games started from a position can,
+// so a board is invalid when the number of each symbol
+// does not fit any number of players, when more than one symbol has completed a line or when the symbol with a line did not make the last move
+func (b *Board) Status() Status {
+	counts := make(map[BoxContent]int, len(PlayerSymbols))
+	lines := make(map[BoxContent][]WinningLine)
+	filled := 0
+
+	for row := range b.Boxes {
+		for col := range b.Boxes[row] {
+			content := b.Boxes[row][col]
+			if content == E {
+				continue
+			}
+
+			filled++
+			counts[content]++
+
+			if b.Gravity && row < b.Rows()-1 && b.Boxes[row+1][col] == E {
+				return Status{State: Invalid, Reason: ErrBoxNotSupported}
+			}
+
+			for _, line := range b.linesStartingAt(row, col) {
+				lines[content] = append(lines[content], line)
+			}
+		}
+	}
+
+	if len(lines) > 1 {
+		return Status{State: Invalid, Reason: ErrMultipleWinners}
+	}
+
+	var winner BoxContent
+	for symbol, symbolLines := range lines {
+		if !shareBox(symbolLines) {
+			return Status{State: Invalid, Reason: ErrSeparateLines}
+		}
+		winner = symbol
+	}
+
+	if !isReachable(counts, filled, winner) {
+		return Status{State: Invalid, Reason: ErrImpossibleCounts}
+	}
+
+	switch {
+	case winner != E && b.Misere:
+		return Status{State: Lost, Symbol: winner, WinningLine: lines[winner][0]}
+	case winner != E:
+		return Status{State: Won, Symbol: winner, WinningLine: lines[winner][0]}
+	case filled == b.Rows()*b.Cols() || !b.CanCompleteLine():
+		return Status{State: Draw}
+	default:
+		return Status{State: InProgress}
+	}
+}
+
+// CanMoveNext checks if the symbol can be the next to move after the symbols on the board
+// in any turn order the symbols can have been placed in, whatever the number of players and whichever of them made the first move
+func (b *Board) CanMoveNext(symbol BoxContent) bool {
+	counts := make(map[BoxContent]int, len(PlayerSymbols))
+	filled := 0
+
+	for row := range b.Boxes {
+		for col := range b.Boxes[row] {
+			if content := b.Boxes[row][col]; content != E {
+				filled++
+				counts[content]++
+			}
+		}
+	}
+
+	for players := 2; players <= len(PlayerSymbols); players++ {
+		for first := 0; first < players; first++ {
+			if isReachableFrom(counts, filled, E, players, first) && PlayerSymbols[(first+filled)%players] == symbol {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// linesStartingAt returns the runs of at least WinCount boxes filled with the same symbol that start from the box on a particular row and col idx
+// a run starts from a box when the box before it along the line holds a different symbol, so that every run is only found once
+func (b *Board) linesStartingAt(rowIdx, colIdx int) []WinningLine {
+	var lines []WinningLine
+
+	checkForWinnerParams := CheckForWinnerParams{
+		PlayerSymbol: b.Boxes[rowIdx][colIdx],
+		RowIdx:       rowIdx,
+		ColIdx:       colIdx,
+	}
+
+	for _, winConditionCheck := range b.WinConditionChecks {
+		forward := winConditionCheck.checks[0]
+		backward := winConditionCheck.checks[1]
+
+		if b.countConsecutive(checkForWinnerParams, backward) > 0 {
+			continue
+		}
+
+		count := 1 + b.countConsecutive(checkForWinnerParams, forward)
+		if count < b.WinCount {
+			continue
+		}
+
+		positions := make([]Position, count)
+		for i := range positions {
+			positions[i] = Position{
+				RowIdx: rowIdx + (int(forward.rowDirection) * i),
+				ColIdx: colIdx + (int(forward.colDirection) * i),
+			}
+		}
+
+		lines = append(lines, WinningLine{winConditionCheck.lineDirection, positions})
+	}
+
+	return lines
+}
+
+// shareBox checks if every line goes through a common box, which is where the move that completed them all was made
+func shareBox(lines []WinningLine) bool {
+	common := make(map[Position]int)
+	for _, line := range lines {
+		for _, position := range line.Positions {
+			common[position]++
+		}
+	}
+
+	for _, count := range common {
+		if count == len(lines) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isReachable checks if the symbols on the board can have been placed by some number of players taking turns in the order of PlayerSymbols
+// with any of the players making the first move, when a symbol has completed a line it must have made the last move, since the game ends there
+func isReachable(counts map[BoxContent]int, filled int, winner BoxContent) bool {
+	for players := 2; players <= len(PlayerSymbols); players++ {
+		for first := 0; first < players; first++ {
+			if isReachableFrom(counts, filled, winner, players, first) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isReachableFrom checks if the symbols on the board can have been placed by a number of players taking turns in the order of PlayerSymbols
+// with the player of the first symbol index making the first move
+func isReachableFrom(counts map[BoxContent]int, filled int, winner BoxContent, players, first int) bool {
+	for i, symbol := range PlayerSymbols {
+		// the player moving in the nth turn of every round has moved once for every round that has reached their turn
+		want := 0
+		if turn := (i - first + players) % players; i < players && filled > turn {
+			want = (filled - turn + players - 1) / players
+		}
+
+		if counts[symbol] != want {
+			return false
+		}
+	}
+
+	return winner == E || PlayerSymbols[(first+filled-1)%players] == winner
+}
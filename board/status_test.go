@@ -0,0 +1,291 @@
+package board
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		position string
+		gravity  bool
+		want     Status
+	}{
+		{
+			"returns in progress on an empty board",
+			"3x3 3 3/3/3 x",
+			false,
+			Status{InProgress, E, WinningLine{}, nil},
+		},
+		{
+			"returns in progress when no line is completed",
+			"3x3 3 x1o/1x1/3 o",
+			false,
+			Status{InProgress, E, WinningLine{}, nil},
+		},
+		{
+			"returns won by x on a diagonal",
+			"3x3 3 xoo/1x1/2x -",
+			false,
+			Status{Won, X, WinningLine{Diagonal, []Position{{0, 0}, {1, 1}, {2, 2}}}, nil},
+		},
+		{
+			"returns won by o on a column",
+			"3x3 3 xxo/x1o/2o -",
+			false,
+			Status{Won, O, WinningLine{Column, []Position{{0, 2}, {1, 2}, {2, 2}}}, nil},
+		},
+		{
+			"returns won by x with two lines completed by the same move",
+			"3x3 3 xxx/oxo/xoo -",
+			false,
+			Status{Won, X, WinningLine{Row, []Position{{0, 0}, {0, 1}, {0, 2}}}, nil},
+		},
+		{
+			"returns lost by x under misere rules",
+			"3x3 3 xxx/oo1/3 - m",
+			false,
+			Status{Lost, X, WinningLine{Row, []Position{{0, 0}, {0, 1}, {0, 2}}}, nil},
+		},
+		{
+			"returns won by triangle with three players",
+			"4x4 3 xot1/oxt1/xot1/4 -",
+			false,
+			Status{Won, T, WinningLine{Column, []Position{{0, 2}, {1, 2}, {2, 2}}}, nil},
+		},
+		{
+			"returns draw when every box is filled without a line",
+			"3x3 3 xox/xox/oxo -",
+			false,
+			Status{Draw, E, WinningLine{}, nil},
+		},
+		{
+			"returns draw when boxes are left but no line can be completed",
+			"3x3 3 xox/xoo/ox1 x",
+			false,
+			Status{Draw, E, WinningLine{}, nil},
+		},
+		{
+			"returns invalid when both symbols have completed a line",
+			"3x3 3 xxx/ooo/3 -",
+			false,
+			Status{Invalid, E, WinningLine{}, ErrMultipleWinners},
+		},
+		{
+			"returns invalid when a symbol has completed two lines that do not meet",
+			"4x4 3 xxx1/oo1o/xxx1/o1oo -",
+			false,
+			Status{Invalid, E, WinningLine{}, ErrSeparateLines},
+		},
+		{
+			"returns in progress when o has made the first move",
+			"3x3 3 o2/1o1/2x x",
+			false,
+			Status{InProgress, E, WinningLine{}, nil},
+		},
+		{
+			"returns won by x when o has made the first move",
+			"3x3 3 xxx/oo1/o2 -",
+			false,
+			Status{Won, X, WinningLine{Row, []Position{{0, 0}, {0, 1}, {0, 2}}}, nil},
+		},
+		{
+			"returns invalid when o has played two more times than x",
+			"3x3 3 oo1/1o1/2x x",
+			false,
+			Status{Invalid, E, WinningLine{}, ErrImpossibleCounts},
+		},
+		{
+			"returns invalid when x has played twice in a row",
+			"3x3 3 xx1/1x1/3 o",
+			false,
+			Status{Invalid, E, WinningLine{}, ErrImpossibleCounts},
+		},
+		{
+			"returns invalid when the symbol with a line did not make the last move",
+			"4x4 3 ooo1/x2x/1x2/3x -",
+			false,
+			Status{Invalid, E, WinningLine{}, ErrImpossibleCounts},
+		},
+		{
+			"returns invalid when a symbol floats above an empty box with gravity",
+			"3x3 3 x2/3/o2 x",
+			true,
+			Status{Invalid, E, WinningLine{}, ErrBoxNotSupported},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, _, err := ParsePosition(test.position)
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			// a position with gravity cannot hold floating symbols, so gravity is only turned on once the board is parsed
+			b.Gravity = test.gravity
+
+			if got := b.Status(); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("unexpected status = %v, want %v", got, test.want)
+			}
+
+		})
+	}
+
+}
+
+func TestCanMoveNext(t *testing.T) {
+	tests := []struct {
+		name     string
+		position string
+		want     bool
+	}{
+		{
+			"returns true for x on an empty board",
+			"3x3 3 3/3/3 x",
+			true,
+		},
+		{
+			"returns true for a later symbol on an empty board since any player can move first",
+			"3x3 3 3/3/3 t",
+			true,
+		},
+		{
+			"returns true for o after a move by x",
+			"3x3 3 x2/3/3 o",
+			true,
+		},
+		{
+			"returns false for x moving twice in a row",
+			"3x3 3 x2/3/3 x",
+			false,
+		},
+		{
+			"returns true for o in a game started by o",
+			"3x3 3 xo1/3/3 o",
+			true,
+		},
+		{
+			"returns true for triangle after x and o in a game between 3 players",
+			"3x3 3 xo1/3/3 t",
+			true,
+		},
+		{
+			"returns false for square when triangle has not moved yet",
+			"3x3 3 xo1/3/3 s",
+			false,
+		},
+		{
+			"returns false when the symbols cannot have been placed by players taking turns",
+			"3x3 3 xx1/3/3 o",
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, toMove, err := ParsePosition(test.position)
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			if got := b.CanMoveNext(toMove); got != test.want {
+				t.Errorf("unexpected CanMoveNext = %v, want %v", got, test.want)
+			}
+
+		})
+	}
+
+}
+
+func TestCanCompleteLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		position string
+		want     bool
+	}{
+		{
+			"returns true on an empty board",
+			"3x3 3 3/3/3 x",
+			true,
+		},
+		{
+			"returns true when a run holds a single symbol and empty boxes",
+			"3x3 3 xox/xoo/o2 x",
+			true,
+		},
+		{
+			"returns false when every run holds more than one symbol",
+			"3x3 3 xox/xoo/ox1 x",
+			false,
+		},
+		{
+			"returns true when a run is only open to a third player",
+			"4x4 3 xoxo/oxot/txot/tox1 o",
+			true,
+		},
+		{
+			"returns false when every run is blocked for every player",
+			"4x4 3 xoxo/oxox/txot/tox1 o",
+			false,
+		},
+		{
+			"returns true when a run longer than the board is wide can still be filled down a column",
+			"4x2 3 xo/ox/2/2 x",
+			true,
+		},
+		{
+			"returns false when the only runs are blocked columns",
+			"4x2 3 xo/ox/xo/2 x",
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, _, err := ParsePosition(test.position)
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			if got := b.CanCompleteLine(); got != test.want {
+				t.Errorf("unexpected CanCompleteLine = %t, want %t", got, test.want)
+			}
+
+		})
+	}
+
+}
+
+func TestCanCompleteLineAfterMoves(t *testing.T) {
+	b, _ := NewBoard(NewBoardParams{WinCount: 3, Rows: 3, Cols: 3})
+	moves := []InsertBoxWithContentParams{
+		{0, 0, X}, {0, 1, O}, {0, 2, X}, {1, 1, O}, {1, 0, X}, {1, 2, O}, {2, 1, X}, {2, 0, O},
+	}
+
+	// the run found open before is blocked by later moves and opened again by undoing them, a fresh board of the same boxes has to agree every time
+	check := func() {
+		t.Helper()
+
+		fresh, _, err := ParsePosition(b.FormatPosition(E))
+		if err != nil {
+			t.Fatalf("unexpected error = %v, want %v", err, nil)
+		}
+
+		if got, want := b.CanCompleteLine(), fresh.CanCompleteLine(); got != want {
+			t.Errorf("unexpected CanCompleteLine on %s = %t, want %t", b.FormatPosition(E), got, want)
+		}
+	}
+
+	for _, move := range moves {
+		b.SelectBox(move)
+		check()
+	}
+
+	for range moves {
+		b.Undo()
+		check()
+	}
+
+}
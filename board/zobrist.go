@@ -0,0 +1,121 @@
+package board
+
+// Symmetry is a way of turning or flipping the board that maps every line onto another line, so that the game played is the same
+type Symmetry int
+
+// Symmetries of a board, the ones that swap rows and columns only apply to square boards
+const (
+	Identity            Symmetry = iota // Board as it is
+	RotateClockwise                     // Board turned a quarter clockwise
+	RotateHalf                          // Board turned upside down
+	RotateAnticlockwise                 // Board turned a quarter anticlockwise
+	MirrorLeftRight                     // Board flipped so that the first column becomes the last
+	MirrorTopBottom                     // Board flipped so that the first row becomes the last
+	Transpose                           // Board flipped along the diagonal, so that rows become columns
+	AntiTranspose                       // Board flipped along the anti-diagonal
+	symmetryCount
+)
+
+// Inverse returns the symmetry that undoes the symmetry
+func (s Symmetry) Inverse() Symmetry {
+	switch s {
+	case RotateClockwise:
+		return RotateAnticlockwise
+	case RotateAnticlockwise:
+		return RotateClockwise
+	default:
+		return s
+	}
+}
+
+// Hash returns the zobrist hash of the boxes on the board, it is kept up to date as moves are made, undone and redone
+// boards of the same size holding the same symbols in the same boxes have the same hash, the rules are not part of it
+func (b *Board) Hash() uint64 {
+	return b.hashes[Identity]
+}
+
+// RecomputeHash works out the hash of the board again from its boxes
+// it is needed after Boxes have been changed directly instead of through SelectBox, Undo and Redo
+func (b *Board) RecomputeHash() {
+	b.hashes = [symmetryCount]uint64{}
+
+	for row := range b.Boxes {
+		for col := range b.Boxes[row] {
+			if b.Boxes[row][col] != E {
+				b.toggleHash(row, col, b.Boxes[row][col])
+			}
+		}
+	}
+}
+
+// Symmetries returns the symmetries that leave the game on the board unchanged
+// a square board has 8, other boards can only be turned upside down or flipped, and with gravity the board can only be flipped left to right
+func (b *Board) Symmetries() []Symmetry {
+	switch {
+	case b.Gravity:
+		return []Symmetry{Identity, MirrorLeftRight}
+	case b.Rows() == b.Cols():
+		return []Symmetry{Identity, RotateClockwise, RotateHalf, RotateAnticlockwise, MirrorLeftRight, MirrorTopBottom, Transpose, AntiTranspose}
+	default:
+		return []Symmetry{Identity, RotateHalf, MirrorLeftRight, MirrorTopBottom}
+	}
+}
+
+// Canonical returns the hash of the canonical form of the board, which is the same for every board that is a symmetry of another
+// the symmetry that turns the board into its canonical form is returned along with it, so that positions can be mapped onto the canonical form with TransformPosition
+func (b *Board) Canonical() (uint64, Symmetry) {
+	symmetries := b.Symmetries()
+
+	canonical, symmetry := b.hashes[symmetries[0]], symmetries[0]
+	for _, s := range symmetries[1:] {
+		if b.hashes[s] < canonical {
+			canonical, symmetry = b.hashes[s], s
+		}
+	}
+
+	return canonical, symmetry
+}
+
+// TransformPosition returns where the box at a position ends up once the board is turned or flipped by the symmetry
+func (b *Board) TransformPosition(p Position, s Symmetry) Position {
+	lastRow, lastCol := b.Rows()-1, b.Cols()-1
+
+	switch s {
+	case RotateClockwise:
+		return Position{p.ColIdx, lastRow - p.RowIdx}
+	case RotateHalf:
+		return Position{lastRow - p.RowIdx, lastCol - p.ColIdx}
+	case RotateAnticlockwise:
+		return Position{lastCol - p.ColIdx, p.RowIdx}
+	case MirrorLeftRight:
+		return Position{p.RowIdx, lastCol - p.ColIdx}
+	case MirrorTopBottom:
+		return Position{lastRow - p.RowIdx, p.ColIdx}
+	case Transpose:
+		return Position{p.ColIdx, p.RowIdx}
+	case AntiTranspose:
+		return Position{lastCol - p.ColIdx, lastRow - p.RowIdx}
+	default:
+		return p
+	}
+}
+
+// toggleHash adds a symbol in the box on a particular row and col idx to the hashes of the board, or takes it out again when it is already in them
+// every symmetry has its own hash so that the canonical form can be found without going over the boxes
+func (b *Board) toggleHash(rowIdx, colIdx int, content BoxContent) {
+	for s := Identity; s < symmetryCount; s++ {
+		b.hashes[s] ^= zobristKey(b.TransformPosition(Position{rowIdx, colIdx}, s), content)
+	}
+}
+
+// zobristKey returns the random number standing for a symbol in the box at a position
+// the numbers are worked out from the position and the symbol with splitmix64, so that they are the same for every board without keeping a table of them
+func zobristKey(p Position, content BoxContent) uint64 {
+	x := uint64(p.RowIdx)<<40 ^ uint64(p.ColIdx)<<16 ^ uint64(content)
+
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+
+	return x ^ (x >> 31)
+}
@@ -0,0 +1,225 @@
+package board
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	tests := []struct {
+		name     string
+		position string
+		moves    []Move
+		undos    int
+		redos    int
+		want     string
+	}{
+		{
+			"returns the hash of the position reached by the moves",
+			"3x3 3 3/3/3 x",
+			[]Move{{1, 1, X}, {0, 0, O}, {2, 2, X}},
+			0,
+			0,
+			"3x3 3 o2/1x1/2x o",
+		},
+		{
+			"returns the hash of the position before the moves undone",
+			"3x3 3 3/3/3 x",
+			[]Move{{1, 1, X}, {0, 0, O}, {2, 2, X}},
+			2,
+			0,
+			"3x3 3 3/1x1/3 o",
+		},
+		{
+			"returns the hash of the position with the moves redone",
+			"3x3 3 3/3/3 x",
+			[]Move{{1, 1, X}, {0, 0, O}, {2, 2, X}},
+			2,
+			1,
+			"3x3 3 o2/1x1/3 x",
+		},
+		{
+			"returns the hash of a position with boxes already filled",
+			"4x3 3 x2/1o1/3/3 x",
+			[]Move{{3, 2, X}},
+			0,
+			0,
+			"4x3 3 x2/1o1/3/2x o",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, _, err := ParsePosition(test.position)
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			for _, move := range test.moves {
+				if err := b.SelectBox(InsertBoxWithContentParams{move.RowIdx, move.ColIdx, move.Content}); err != nil {
+					t.Fatalf("unexpected error = %v, want %v", err, nil)
+				}
+			}
+
+			for i := 0; i < test.undos; i++ {
+				b.Undo()
+			}
+
+			for i := 0; i < test.redos; i++ {
+				b.Redo()
+			}
+
+			want, _, err := ParsePosition(test.want)
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			if b.Hash() != want.Hash() {
+				t.Errorf("unexpected hash = %d, want %d", b.Hash(), want.Hash())
+			}
+
+			data, err := json.Marshal(b)
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			var unmarshalled Board
+			if err := json.Unmarshal(data, &unmarshalled); err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			if unmarshalled.Hash() != want.Hash() {
+				t.Errorf("unexpected hash after unmarshalling = %d, want %d", unmarshalled.Hash(), want.Hash())
+			}
+
+		})
+	}
+
+}
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		name      string
+		positions [2]string
+		want      bool
+	}{
+		{
+			"returns the same hash for corners of a square board",
+			[2]string{"3x3 3 x2/3/3 o", "3x3 3 3/3/2x o"},
+			true,
+		},
+		{
+			"returns the same hash for a square board flipped along the diagonal",
+			[2]string{"4x4 3 1x2/1o2/4/4 x", "4x4 3 4/xo2/4/4 x"},
+			true,
+		},
+		{
+			"returns the same hash for a square board turned a quarter",
+			[2]string{"4x4 3 xo2/4/4/4 x", "4x4 3 3x/3o/4/4 x"},
+			true,
+		},
+		{
+			"returns a different hash for a corner and an edge",
+			[2]string{"3x3 3 x2/3/3 o", "3x3 3 1x1/3/3 o"},
+			false,
+		},
+		{
+			"returns a different hash for the same boxes with a different symbol",
+			[2]string{"3x3 3 x2/3/3 o", "3x3 3 o2/3/3 x"},
+			false,
+		},
+		{
+			"returns the same hash for a board that is not square turned upside down",
+			[2]string{"3x4 3 x3/4/4 o", "3x4 3 4/4/3x o"},
+			true,
+		},
+		{
+			"returns a different hash for a board that is not square flipped along the diagonal",
+			[2]string{"3x4 3 1x2/4/4 o", "3x4 3 4/x3/4 o"},
+			false,
+		},
+		{
+			"returns the same hash for a board with gravity flipped left to right",
+			[2]string{"3x3 3 3/x2/xo1 o g", "3x3 3 3/2x/1ox o g"},
+			true,
+		},
+		{
+			"returns a different hash for a board with gravity turned a quarter",
+			[2]string{"3x3 3 3/3/xx1 o g", "3x3 3 3/x2/x2 o g"},
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var hashes [2]uint64
+			for i, position := range test.positions {
+				b, _, err := ParsePosition(position)
+				if err != nil {
+					t.Fatalf("unexpected error = %v, want %v", err, nil)
+				}
+
+				hash, symmetry := b.Canonical()
+				hashes[i] = hash
+
+				// the board turned by the symmetry returned has to hash to the canonical hash
+				canonical, _ := NewBoard(NewBoardParams{WinCount: b.WinCount, Rows: b.Rows(), Cols: b.Cols()})
+				if symmetry == RotateClockwise || symmetry == RotateAnticlockwise || symmetry == Transpose || symmetry == AntiTranspose {
+					canonical, _ = NewBoard(NewBoardParams{WinCount: b.WinCount, Rows: b.Cols(), Cols: b.Rows()})
+				}
+
+				for row := range b.Boxes {
+					for col := range b.Boxes[row] {
+						to := b.TransformPosition(Position{row, col}, symmetry)
+						canonical.Boxes[to.RowIdx][to.ColIdx] = b.Boxes[row][col]
+					}
+				}
+				canonical.RecomputeHash()
+
+				if canonical.Hash() != hash {
+					t.Errorf("unexpected hash of %s turned by %d = %d, want %d", position, symmetry, canonical.Hash(), hash)
+				}
+			}
+
+			if got := hashes[0] == hashes[1]; got != test.want {
+				t.Errorf("unexpected same canonical hash = %t, want %t", got, test.want)
+			}
+
+		})
+	}
+
+}
+
+func TestTransformPosition(t *testing.T) {
+	tests := []struct {
+		name     string
+		symmetry Symmetry
+		want     Position
+	}{
+		{"keeps the box with the identity", Identity, Position{0, 1}},
+		{"turns the box a quarter clockwise", RotateClockwise, Position{1, 3}},
+		{"turns the box upside down", RotateHalf, Position{3, 2}},
+		{"turns the box a quarter anticlockwise", RotateAnticlockwise, Position{2, 0}},
+		{"flips the box left to right", MirrorLeftRight, Position{0, 2}},
+		{"flips the box top to bottom", MirrorTopBottom, Position{3, 1}},
+		{"flips the box along the diagonal", Transpose, Position{1, 0}},
+		{"flips the box along the anti-diagonal", AntiTranspose, Position{2, 3}},
+	}
+
+	b, _ := NewBoard(NewBoardParams{WinCount: 3, Rows: 4, Cols: 4})
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := b.TransformPosition(Position{0, 1}, test.symmetry)
+			if got != test.want {
+				t.Errorf("unexpected position = %v, want %v", got, test.want)
+			}
+
+			if back := b.TransformPosition(got, test.symmetry.Inverse()); back != (Position{0, 1}) {
+				t.Errorf("unexpected position turned back = %v, want %v", back, Position{0, 1})
+			}
+
+		})
+	}
+
+}
@@ -0,0 +1,21 @@
+// package main starts a HTTP server that hosts tic tac toe games through a JSON API
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/dev-amos/tictactoe/server"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to serve the API on")
+	flag.Parse()
+
+	log.Printf("serving tic tac toe API on %s", *addr)
+
+	if err := http.ListenAndServe(*addr, server.NewServer()); err != nil {
+		log.Fatalf("serve API failed, err=%v", err)
+	}
+}
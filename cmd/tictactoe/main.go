@@ -3,127 +3,774 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"net"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/dev-amos/tictactoe/board"
+	"github.com/dev-amos/tictactoe/game"
 	"github.com/dev-amos/tictactoe/player"
+	"github.com/dev-amos/tictactoe/player/ai"
+	"github.com/dev-amos/tictactoe/player/mcts"
 	"github.com/dev-amos/tictactoe/player/real"
+	"github.com/dev-amos/tictactoe/record"
+	"github.com/dev-amos/tictactoe/solver"
 	"github.com/dev-amos/tictactoe/view"
+	"github.com/dev-amos/tictactoe/view/network"
 	"github.com/dev-amos/tictactoe/view/terminal"
+	"github.com/dev-amos/tictactoe/view/web"
+)
+
+// largeBoardBoxes is the number of boxes above which a computer player searches with monte carlo tree search instead of looking ahead a few moves
+const largeBoardBoxes = 49
+
+// computerThinkingTime is the time a computer player spends searching for every move, the best move found by then is played
+const computerThinkingTime = time.Second
+
+// perfectBoardBoxes is the number of boxes up to which a perfect computer player solves the board, bigger boards take too long to solve
+const perfectBoardBoxes = 16
+
+// search depths and chances of filling a random box of the computer players weaker than hard
+const (
+	easySearchDepth   = 1
+	easyBlunder       = 0.4
+	mediumSearchDepth = 2
+	mediumBlunder     = 0.1
 )
 
 //TODO: handle packaging the code for running correctly
 func main() {
 
+	// the first argument picks the command, a game is played locally when it is left out
+	command := "play"
+	args := os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
 	view := terminal.Terminal{
 		InputReader: bufio.NewReader(os.Stdin),
 	}
 
-	players, err := createPlayers(view)
+	switch command {
+	case "play":
+		playLocal(view, args)
+	case "host":
+		hostGame(view, args)
+	case "join":
+		joinGame(view, args)
+	case "load":
+		loadGame(view, args)
+	case "replay":
+		replayGame(view, args)
+	case "analyze":
+		analyzePosition(args)
+	default:
+		log.Fatalf("unknown command %q, expected one of play, host, join, load, replay or analyze", command)
+	}
+}
+
+// rulesFlags are the command line flags that set up the rules of a new game
+type rulesFlags struct {
+	winCount *int
+	gravity  *bool
+	misere   *bool
+}
+
+// addRulesFlags defines the flags that set up the rules of a new game on a command's flag set
+func addRulesFlags(fs *flag.FlagSet) rulesFlags {
+	return rulesFlags{
+		winCount: fs.Int("win", 0, "number of boxes in a line needed to win, asked for when not set"),
+		gravity:  fs.Bool("gravity", false, "pieces fall to the lowest empty box of the chosen column, as in connect four"),
+		misere:   fs.Bool("misere", false, "the player who completes a line loses instead of winning"),
+	}
+}
+
+// rulesFlagSet checks if any of the flags that set up the rules of a new game has been given on the command line
+func rulesFlagSet(fs *flag.FlagSet) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "win" || f.Name == "gravity" || f.Name == "misere" {
+			set = true
+		}
+	})
+
+	return set
+}
+
+// createBoard asks for the dimensions of the board, and the win count when it is not set as a flag, and creates the board
+func createBoard(v view.View, rules rulesFlags) (*board.Board, error) {
+	rows, cols, err := v.GetDimensions()
+	if err != nil {
+		return nil, err
+	}
+
+	winCount := *rules.winCount
+	if winCount == 0 {
+		winCount, err = v.GetWinCount()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	newBoardParams := board.NewBoardParams{
+		WinCount: winCount,
+		Rows:     rows,
+		Cols:     cols,
+		Gravity:  *rules.gravity,
+		Misere:   *rules.misere,
+	}
+
+	return board.NewBoard(newBoardParams)
+}
+
+// playLocal plays a game where every player shares the same terminal, or the same browser page when the game is served on the web
+func playLocal(v view.View, args []string) {
+	fs := flag.NewFlagSet("play", flag.ExitOnError)
+	rules := addRulesFlags(fs)
+	webAddr := fs.String("web", "", "address to serve the game on for playing from a browser, e.g. :8080")
+	saveFile := fs.String("save", "", "file the game is saved to after every move, so that it can be resumed with the load command")
+	position := fs.String("position", "", `position string to start the game from instead of an empty board, e.g. "3x3 3 x1o/1x1/3 o"`)
+	fs.Parse(args)
+
+	// the rules of a game started from a position come from the position string, rules flags given with it would be ignored
+	if *position != "" && rulesFlagSet(fs) {
+		log.Fatalf("-position cannot be combined with -win, -gravity or -misere, the rules are part of the position string")
+	}
+
+	// the page keeps being served after the game has ended so that the result can still be seen
+	if *webAddr != "" {
+		listener, err := net.Listen("tcp", *webAddr)
+		if err != nil {
+			log.Fatalf("listen for web page failed, err=%v", err)
+		}
+
+		webView := web.NewWeb()
+		serveErr := make(chan error, 1)
+		go func() {
+			serveErr <- http.Serve(listener, webView)
+		}()
+
+		fmt.Printf("Open http://%s in a browser to play\n", browserAddress(*webAddr))
+		v = webView
+
+		defer func() {
+			fmt.Println("The game has ended, press Ctrl+C to stop serving the page")
+			log.Fatalf("serve web page failed, err=%v", <-serveErr)
+		}()
+	}
+
+	// x moves first on an empty board
+	var b *board.Board
+	toMove := board.X
+	var err error
+	if *position != "" {
+		b, toMove, err = board.ParsePosition(*position)
+		if err == nil {
+			err = checkStartPosition(b, toMove)
+		}
+	} else {
+		b, err = createBoard(v, rules)
+	}
+	if err != nil {
+		log.Fatalf("create board failed, err=%v", err)
+	}
+
+	players, err := createPlayers(v, b, toMove)
 	if err != nil {
 		log.Fatalf("create players failed, err=%v", err)
 	}
 
-	dimension, err := view.GetDimensions()
+	if *position != "" {
+		if players, err = startWith(players, toMove); err != nil {
+			log.Fatalf("start from position failed, err=%v", err)
+		}
+	}
+
+	newGameParams := game.NewGameParams{
+		Board:   b,
+		Players: players,
+	}
+
+	g, err := game.NewGame(newGameParams)
 	if err != nil {
-		log.Fatalf("get dimensions from user input failed, err=%v", err)
+		log.Fatalf("create game failed, err=%v", err)
 	}
 
-	newBoardParams := board.NewBoardParams{
-		WinCount:   3,
-		Dimensions: dimension,
+	startGame(g, v, *saveFile)
+}
+
+// loadGame resumes a game saved with the save flag, it keeps being saved to the same file as it is played
+func loadGame(v view.View, args []string) {
+	fs := flag.NewFlagSet("load", flag.ExitOnError)
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		log.Fatalf("load needs the file the game was saved to, e.g. tictactoe load game.json")
 	}
+	saveFile := fs.Arg(0)
 
-	b, err := board.NewBoard(newBoardParams)
+	rec, err := readRecord(saveFile)
+	if err != nil {
+		log.Fatalf("read saved game failed, err=%v", err)
+	}
+
+	// saved players take their seats again in the same order, computers play at the level they were saved with
+	// the players are saved in turn order starting with the player who made the first move
+	toMove := rec.Players[len(rec.Board.History())%len(rec.Players)].Symbol
+	symbols := make([]board.BoxContent, 0, len(rec.Players))
+	for _, p := range rec.Players {
+		symbols = append(symbols, p.Symbol)
+	}
+
+	players := make([]player.Player, 0, len(rec.Players))
+	for i, p := range rec.Players {
+		if p.Type == record.ComputerPlayer {
+			players = append(players, newComputerPlayer(rec.Board, toMove, p.Name, symbols, i, p.Level))
+			continue
+		}
+
+		newPlayerParams := real.NewPlayerParams{
+			Name:   p.Name,
+			Symbol: p.Symbol,
+		}
+		players = append(players, real.NewPlayer(newPlayerParams))
+	}
+
+	g, err := rec.NewGame(record.NewGameParams{Players: players})
+	if err != nil {
+		log.Fatalf("resume saved game failed, err=%v", err)
+	}
+
+	startGame(g, v, saveFile)
+}
+
+// replayGame steps through a saved game, at the pace the user chooses or on its own with autoplay
+func replayGame(v view.View, args []string) {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	autoplay := fs.Bool("autoplay", false, "step through the moves on their own instead of waiting for the user")
+	speed := fs.Duration("speed", time.Second, "time each move is shown for with autoplay")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		log.Fatalf("replay needs the file the game was saved to, e.g. tictactoe replay game.json")
+	}
+
+	replayer, ok := v.(view.Replayer)
+	if !ok {
+		log.Fatalf("view cannot replay games")
+	}
+
+	rec, err := readRecord(fs.Arg(0))
+	if err != nil {
+		log.Fatalf("read saved game failed, err=%v", err)
+	}
+
+	replay, err := rec.NewReplay()
+	if err != nil {
+		log.Fatalf("replay saved game failed, err=%v", err)
+	}
+
+	showReplayStep(replayer, replay)
+
+	if *autoplay {
+		for replay.Step() < replay.Len() {
+			time.Sleep(*speed)
+			if err := replay.Next(); err != nil {
+				log.Fatalf("replay move failed, err=%v", err)
+			}
+			showReplayStep(replayer, replay)
+		}
+		return
+	}
+
+	for {
+		step, err := replayer.GetReplayStep()
+		if err != nil {
+			log.Fatalf("get replay step failed, err=%v", err)
+		}
+
+		// stepping past either end of the game leaves the board where it is
+		switch step {
+		case view.NextStep:
+			err = replay.Next()
+		case view.PreviousStep:
+			err = replay.Previous()
+		case view.FirstStep:
+			err = replay.Seek(0)
+		case view.LastStep:
+			err = replay.Seek(replay.Len())
+		case view.QuitReplay:
+			return
+		}
+		if err != nil && !errors.Is(err, record.ErrReplayAtStart) && !errors.Is(err, record.ErrReplayAtEnd) {
+			log.Fatalf("replay move failed, err=%v", err)
+		}
+
+		showReplayStep(replayer, replay)
+	}
+}
+
+// analyzePosition prints the value of a position with perfect play, the best moves and the value of every move that can be made
+func analyzePosition(args []string) {
+	fs := flag.NewFlagSet("analyze", flag.ExitOnError)
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		log.Fatalf(`analyze needs a position string, e.g. tictactoe analyze "4x4 3 4/4/4/4 x"`)
+	}
+
+	b, toMove, err := board.ParsePosition(fs.Arg(0))
+	if err != nil {
+		log.Fatalf("read position failed, err=%v", err)
+	}
+
+	s := solver.NewSolver()
+	moveValues, err := s.Analyze(solver.SolveParams{Board: b, ToMove: toMove})
+	if err != nil {
+		log.Fatalf("analyze position failed, err=%v", err)
+	}
+
+	// the moves are ordered best first, so the position is worth as much as the first move
+	value := moveValues[0].Value
+	symbol, _ := toMove.MarshalText()
+	fmt.Printf("%s to move: %s\n", symbol, value)
+
+	var bestMoves []string
+	for _, moveValue := range moveValues {
+		if moveValue.Value == value {
+			bestMoves = append(bestMoves, board.FormatCoordinate(moveValue.Position))
+		}
+	}
+	fmt.Printf("Best moves: %s\n", strings.Join(bestMoves, " "))
+
+	fmt.Println("Every move:")
+	for _, moveValue := range moveValues {
+		fmt.Printf("  %-4s %s\n", board.FormatCoordinate(moveValue.Position), moveValue.Value)
+	}
+	fmt.Printf("%d positions searched\n", s.Nodes())
+}
+
+// showReplayStep prints the board a replay has stepped to and the move that led to it, along with the result once the game is over
+func showReplayStep(v view.Replayer, replay *record.Replay) {
+	g := replay.Game()
+	v.PrintBoard(*g.Board())
+
+	declareReplayMoveParams := view.DeclareReplayMoveParams{
+		MoveNumber: replay.Step(),
+		MoveCount:  replay.Len(),
+	}
+
+	if move, p := replay.LastMove(); p != nil {
+		declareReplayMoveParams.PlayerName = p.GetName()
+		declareReplayMoveParams.Coordinate = board.FormatCoordinate(board.Position{RowIdx: move.RowIdx, ColIdx: move.ColIdx})
+		declareReplayMoveParams.CompletesLine = len(g.WinningLine().Positions) > 0
+	}
+
+	v.DeclareReplayMove(declareReplayMoveParams)
+
+	if g.Status() != game.InProgress {
+		declareResult(g, v)
+	}
+}
+
+// readRecord reads the game saved to the file
+func readRecord(saveFile string) (record.Record, error) {
+	f, err := os.Open(saveFile)
+	if err != nil {
+		return record.Record{}, err
+	}
+	defer f.Close()
+
+	return record.Read(f)
+}
+
+// saveGame writes the game to the file, the file is replaced in one go so that an interrupted save does not corrupt an earlier one
+func saveGame(g *game.Game, saveFile string) error {
+	tmpFile := saveFile + ".tmp"
+
+	f, err := os.Create(tmpFile)
+	if err != nil {
+		return err
+	}
+
+	if err := record.Write(f, g); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile, saveFile)
+}
+
+// browserAddress returns the address a browser on the same machine can open for the address the game is served on
+func browserAddress(addr string) string {
+	if strings.HasPrefix(addr, ":") {
+		return "localhost" + addr
+	}
+
+	return addr
+}
+
+// hostGame waits for a second player to join over TCP and plays a game against them, the host's board is the only one moves are played on
+func hostGame(v view.View, args []string) {
+	fs := flag.NewFlagSet("host", flag.ExitOnError)
+	rules := addRulesFlags(fs)
+	addr := fs.String("addr", ":7777", "address to listen on for the joining player")
+	fs.Parse(args)
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("listen for joining player failed, err=%v", err)
+	}
+	defer listener.Close()
+
+	fmt.Printf("Waiting for a player to join on %s\n", listener.Addr())
+
+	conn, err := listener.Accept()
+	if err != nil {
+		log.Fatalf("accept joining player failed, err=%v", err)
+	}
+	defer conn.Close()
+
+	newHostParams := network.NewHostParams{
+		Local:        v,
+		Conn:         conn,
+		RemoteSymbol: board.O,
+	}
+
+	host, err := network.NewHost(newHostParams)
+	if err != nil {
+		log.Fatalf("greet joining player failed, err=%v", err)
+	}
+
+	fmt.Printf("%s has joined the game\n", host.RemoteName())
+
+	b, err := createBoard(v, rules)
 	if err != nil {
 		log.Fatalf("create board failed, err=%v", err)
 	}
 
-	startGame(players, b, view)
+	localPlayer, err := createPlayer(v, b, board.X, 1, []board.BoxContent{board.X, board.O})
+	if err != nil {
+		log.Fatalf("create player failed, err=%v", err)
+	}
+
+	newPlayerParams := real.NewPlayerParams{
+		Name:   host.RemoteName(),
+		Symbol: board.O,
+	}
+
+	newGameParams := game.NewGameParams{
+		Board:   b,
+		Players: []player.Player{localPlayer, real.NewPlayer(newPlayerParams)},
+	}
+
+	g, err := game.NewGame(newGameParams)
+	if err != nil {
+		log.Fatalf("create game failed, err=%v", err)
+	}
+
+	startGame(g, host, "")
 }
 
-// createPlayers takes 2 player names as input from command line and creates 2 player models
-func createPlayers(v view.View) ([]player.Player, error) {
-	firstPlayerName, err := v.GetUserName(1)
+// joinGame connects to a game hosted on another machine and plays in it as the second player
+func joinGame(v view.View, args []string) {
+	fs := flag.NewFlagSet("join", flag.ExitOnError)
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		log.Fatalf("join needs the address of the host, e.g. tictactoe join localhost:7777")
+	}
+
+	name, err := v.GetUserName(2)
+	if err != nil {
+		log.Fatalf("get name from user input failed, err=%v", err)
+	}
+
+	conn, err := net.Dial("tcp", fs.Arg(0))
+	if err != nil {
+		log.Fatalf("connect to host failed, err=%v", err)
+	}
+	defer conn.Close()
+
+	joinParams := network.JoinParams{
+		Local: v,
+		Conn:  conn,
+		Name:  name,
+	}
+
+	if err := network.Join(joinParams); err != nil {
+		log.Fatalf("play hosted game failed, err=%v", err)
+	}
+}
+
+// createPlayers asks how many players are joining and creates a player model for each, the symbol to move is the first to move on the board
+func createPlayers(v view.View, b *board.Board, toMove board.BoxContent) ([]player.Player, error) {
+	numberOfPlayers, err := v.GetNumberOfPlayers(len(board.PlayerSymbols))
 	if err != nil {
 		return nil, err
 	}
 
-	newPlayerParams := real.NewPlayerParams{
-		Name:   firstPlayerName,
-		Symbol: board.X,
+	if numberOfPlayers < 2 || numberOfPlayers > len(board.PlayerSymbols) {
+		return nil, fmt.Errorf("number of players must be between 2 and %d", len(board.PlayerSymbols))
+	}
+
+	symbols := board.PlayerSymbols[:numberOfPlayers]
+	players := make([]player.Player, 0, len(symbols))
+
+	for i := range symbols {
+		p, err := createPlayer(v, b, toMove, i+1, symbols)
+		if err != nil {
+			return nil, err
+		}
+		players = append(players, p)
 	}
-	player1 := real.NewPlayer(newPlayerParams)
 
-	secondPlayerName, err := v.GetUserName(2)
+	return players, nil
+}
+
+// createPlayer creates the player model for the nth seat out of the symbols handed out in turn order
+// the seat is either filled by the computer at the level chosen or by a human whose name is taken as input from command line
+func createPlayer(v view.View, b *board.Board, toMove board.BoxContent, playerCount int, symbols []board.BoxContent) (player.Player, error) {
+	i := playerCount - 1
+	symbol := symbols[i]
+
+	getSeatParams := view.GetSeatParams{
+		PlayerCount: playerCount,
+		Levels:      computerLevels(b, toMove, len(symbols)),
+	}
+
+	seat, err := v.GetSeat(getSeatParams)
 	if err != nil {
 		return nil, err
 	}
 
-	newPlayerParams = real.NewPlayerParams{
-		Name:   secondPlayerName,
-		Symbol: board.O,
+	if seat.Computer {
+		return newComputerPlayer(b, toMove, fmt.Sprintf("Computer %d (%s)", playerCount, seat.Level), symbols, i, seat.Level), nil
+	}
+
+	name, err := v.GetUserName(playerCount)
+	if err != nil {
+		return nil, err
+	}
+
+	newPlayerParams := real.NewPlayerParams{
+		Name:   name,
+		Symbol: symbol,
+	}
+
+	return real.NewPlayer(newPlayerParams), nil
+}
+
+// checkStartPosition checks that a game can be started from the board of a position string with the symbol to move
+// the board has to be valid and not already decided, and the symbol has to be able to move next after the symbols on it
+func checkStartPosition(b *board.Board, toMove board.BoxContent) error {
+	switch status := b.Status(); status.State {
+	case board.Invalid:
+		return status.Reason
+	case board.InProgress:
+		if !b.CanMoveNext(toMove) {
+			return board.ErrWrongPlayerToMove
+		}
+		return nil
+	default:
+		return fmt.Errorf("position is already %s", status.State)
+	}
+}
+
+// startWith returns the players in the same turn order, starting with the player who plays with the symbol
+func startWith(players []player.Player, symbol board.BoxContent) ([]player.Player, error) {
+	for i, p := range players {
+		if p.GetSymbol() == symbol {
+			return append(players[i:len(players):len(players)], players[:i]...), nil
+		}
+	}
+
+	return nil, errors.New("no player plays with the symbol to move")
+}
+
+// computerLevels returns the levels a computer can play at in a game on the board with the symbol to move between the number of players
+func computerLevels(b *board.Board, toMove board.BoxContent, numberOfPlayers int) []player.Level {
+	levels := make([]player.Level, 0, len(player.Levels))
+	for _, level := range player.Levels {
+		if level != player.Perfect || canPlayPerfectly(b, toMove, numberOfPlayers) {
+			levels = append(levels, level)
+		}
+	}
+
+	return levels
+}
+
+// canPlayPerfectly checks if the solver can play a game on the board with the symbol to move between the number of players
+// the solver only plays games between x and o started by x, and bigger boards take too long to solve
+func canPlayPerfectly(b *board.Board, toMove board.BoxContent, numberOfPlayers int) bool {
+	return numberOfPlayers == 2 && b.Rows()*b.Cols() <= perfectBoardBoxes && solver.NextToMove(b) == toMove
+}
+
+// newComputerPlayer creates a computer player playing at the level for the ith of the symbols handed out in turn order on the board with the symbol to move
+// a perfect computer in a game the solver cannot play, such as one loaded from a hand-edited save, plays and reports itself as hard instead
+func newComputerPlayer(b *board.Board, toMove board.BoxContent, name string, symbols []board.BoxContent, i int, level player.Level) player.Player {
+	if level == player.Perfect && !canPlayPerfectly(b, toMove, len(symbols)) {
+		fmt.Printf("%s cannot play perfectly on this board and plays at the %s level instead\n", name, player.Hard)
+		level = player.Hard
+	}
+
+	// opponents are listed in the order they move after this player
+	opponents := make([]board.BoxContent, 0, len(symbols)-1)
+	opponents = append(opponents, symbols[i+1:]...)
+	opponents = append(opponents, symbols[:i]...)
+
+	newPlayerParams := ai.NewPlayerParams{
+		Name:      name,
+		Symbol:    symbols[i],
+		Opponents: opponents,
+		Level:     level,
+	}
+
+	switch level {
+	case player.Easy:
+		newPlayerParams.MaxDepth = easySearchDepth
+		newPlayerParams.Blunder = easyBlunder
+		return ai.NewPlayer(newPlayerParams)
+	case player.Medium:
+		newPlayerParams.MaxDepth = mediumSearchDepth
+		newPlayerParams.Blunder = mediumBlunder
+		return ai.NewPlayer(newPlayerParams)
+	case player.Perfect:
+		return solver.NewPlayer(solver.NewPlayerParams{Name: name, Symbol: symbols[i]})
+	}
+
+	// looking ahead a few moves on large boards misses most threats, random playouts see further
+	if b.Rows()*b.Cols() > largeBoardBoxes {
+		mctsPlayerParams := mcts.NewPlayerParams{
+			Name:      name,
+			Symbol:    symbols[i],
+			Opponents: opponents,
+			Level:     level,
+		}
+
+		return mcts.NewPlayer(mctsPlayerParams)
 	}
-	player2 := real.NewPlayer(newPlayerParams)
 
-	return []player.Player{player1, player2}, nil
+	// the search looks further ahead until the thinking time is up, small boards are searched until the end of the game so the computer plays perfectly
+	return ai.NewPlayer(newPlayerParams)
 }
 
-// startGame will get the players to choose their move on the tic tac toe board and constantly checks for win condition at every move
-func startGame(players []player.Player, b *board.Board, v view.View) {
+// startGame drives the game by getting the players to choose their move on the tic tac toe board until the game has ended
+// when a save file is given the game is saved to it after every move, including moves taken back or replayed
+func startGame(g *game.Game, v view.View, saveFile string) {
 
-	dimension := len(b.Boxes)
-	availableMoves := dimension * dimension
-	playerIdx := 0
+	for g.Status() == game.InProgress {
+		if saveFile != "" {
+			if err := saveGame(g, saveFile); err != nil {
+				log.Printf("save game failed, err=%v", err)
+			}
+		}
 
-	// game ends when all possible moves have been made leading to a draw or when a player has won
-	for availableMoves > 0 {
-		player := players[playerIdx]
+		currentPlayer := g.CurrentPlayer()
 
-		v.PrintBoard(*b)
+		v.PrintBoard(*g.Board())
 
 		getUserToSelectBoxParams := view.GetUserToSelectBoxParams{
-			PlayerName:   player.GetName(),
-			PlayerSymbol: player.GetSymbol(),
+			PlayerName:   currentPlayer.GetName(),
+			PlayerSymbol: currentPlayer.GetSymbol(),
 		}
 
-		// get player selection on the box position to place their symbol
-		idxChoice, err := v.GetUserToSelectBox(getUserToSelectBoxParams)
-		if err != nil {
+		// get player selection on the box position to place their symbol, computer players choose without being prompted
+		var idxChoice int
+		var err error
+		if computer, ok := currentPlayer.(player.Computer); ok {
+			ctx, cancel := context.WithTimeout(context.Background(), computerThinkingTime)
+			idxChoice, err = computer.ChooseBox(ctx, g.Board())
+			cancel()
+		} else if g.Board().Gravity {
+			idxChoice, err = v.GetUserToSelectColumn(getUserToSelectBoxParams)
+		} else {
+			idxChoice, err = v.GetUserToSelectBox(getUserToSelectBoxParams)
+		}
+		if errors.Is(err, view.ErrUndoRequested) {
+			takeBack(g)
+			continue
+		} else if errors.Is(err, view.ErrRedoRequested) {
+			replayTakenBack(g)
+			continue
+		} else if err != nil {
 			log.Fatalf("get user selection failed, err=%v", err)
 		}
 
-		// get selected box's row and col index
-		selectedBoardRowIdx := (idxChoice - 1) / dimension
-		selectedBoardColIdx := (idxChoice - 1) % dimension
+		// the same player is asked again when the move cannot be played, such as a box that is occupied or not on the board
+		if err := g.Play(idxChoice); err != nil {
+			if _, ok := currentPlayer.(player.Computer); ok {
+				log.Fatalf("play computer move failed, err=%v", err)
+			}
+			v.DeclareInvalidMove(err)
+		}
+	}
 
-		insertBoxWithContentParams := board.InsertBoxWithContentParams{
-			RowIdx:  selectedBoardRowIdx,
-			ColIdx:  selectedBoardColIdx,
-			Content: player.GetSymbol(),
+	if saveFile != "" {
+		if err := saveGame(g, saveFile); err != nil {
+			log.Printf("save game failed, err=%v", err)
 		}
+	}
 
-		// populate board with the choice
-		b.SelectBox(insertBoxWithContentParams)
+	v.PrintBoard(*g.Board())
+	declareResult(g, v)
+}
 
-		checkForWinnerParams := board.CheckForWinnerParams{
-			PlayerSymbol: player.GetSymbol(),
-			RowIdx:       selectedBoardRowIdx,
-			ColIdx:       selectedBoardColIdx,
+// declareResult announces how a game that is over has ended
+func declareResult(g *game.Game, v view.View) {
+	switch g.Status() {
+	case game.Won:
+		if loser := g.Loser(); loser != nil {
+			v.DeclareLoser(loser.GetName())
 		}
+		v.DeclareWinner(g.Winner().GetName())
+	case game.Lost:
+		v.DeclareLoser(g.Loser().GetName())
+	case game.Draw:
+		v.DeclareDraw()
+	}
+}
 
-		// check if player's move has made him/her the winner
-		if b.CheckForWinner(checkForWinnerParams) {
-			v.PrintBoard(*b)
-			v.DeclareWinner(player.GetName())
+// takeBack undoes the last move, along with any computer moves made after the last human move, so that the turn returns to a human player
+func takeBack(g *game.Game) {
+	if err := g.Undo(); err != nil {
+		return
+	}
+
+	for {
+		if _, ok := g.CurrentPlayer().(player.Computer); !ok {
+			return
+		}
+		if err := g.Undo(); err != nil {
 			return
 		}
+	}
+}
 
-		// switch to next player
-		playerIdx = (playerIdx + 1) % len(players)
-		availableMoves--
+// replayTakenBack redoes the last undone move, along with the computer moves that followed it, so that the turn returns to a human player
+func replayTakenBack(g *game.Game) {
+	if err := g.Redo(); err != nil {
+		return
 	}
 
-	v.DeclareDraw()
+	for g.Status() == game.InProgress {
+		if _, ok := g.CurrentPlayer().(player.Computer); !ok {
+			return
+		}
+		if err := g.Redo(); err != nil {
+			return
+		}
+	}
 }
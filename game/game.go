@@ -0,0 +1,257 @@
+// Package game contains the rules engine that takes turns between players on a tic tac toe board
+package game
+
+import (
+	"errors"
+
+	"github.com/dev-amos/tictactoe/board"
+	"github.com/dev-amos/tictactoe/player"
+)
+
+var (
+	ErrGameOver          = errors.New("game is over and no more moves can be played")
+	ErrNotEnoughPlayers  = errors.New("game needs at least 2 players")
+	ErrBoardNotSpecified = errors.New("game needs a board to be played on")
+)
+
+// Status is the state of a tic tac toe game
+type Status int
+
+// States of a tic tac toe game
+const (
+	InProgress Status = iota // Game is waiting for the current player to move
+	Won                      // Game has ended with a winner
+	Draw                     // Game has ended with no winner and no line left that can be completed
+	Lost                     // Game has ended with a loser under misere rules and every other player sharing the win
+)
+
+// String returns the readable name of a game status
+func (s Status) String() string {
+	switch s {
+	case InProgress:
+		return "in progress"
+	case Won:
+		return "won"
+	case Draw:
+		return "draw"
+	case Lost:
+		return "lost"
+	default:
+		return "unknown"
+	}
+}
+
+// Game owns a tic tac toe board and the players taking turns on it
+type Game struct {
+	board          *board.Board
+	players        []player.Player
+	playerIdx      int
+	availableMoves int
+	status         Status
+	winner         player.Player
+	loser          player.Player
+	winningLine    board.WinningLine
+}
+
+// NewGameParams defines the structure for the parameters needed to create a new game
+type NewGameParams struct {
+	Board   *board.Board
+	Players []player.Player
+}
+
+// NewGame creates a new game where the players take turns in the order given, starting with the first player
+func NewGame(p NewGameParams) (*Game, error) {
+
+	if p.Board == nil {
+		return nil, ErrBoardNotSpecified
+	} else if len(p.Players) < 2 {
+		return nil, ErrNotEnoughPlayers
+	}
+
+	availableMoves := 0
+	for row := range p.Board.Boxes {
+		for col := range p.Board.Boxes[row] {
+			if p.Board.Boxes[row][col] == board.E {
+				availableMoves++
+			}
+		}
+	}
+
+	g := &Game{
+		board:          p.Board,
+		players:        p.Players,
+		availableMoves: availableMoves,
+		status:         InProgress,
+	}
+
+	if availableMoves == 0 || !p.Board.CanCompleteLine() {
+		g.status = Draw
+	}
+
+	return g, nil
+}
+
+// Board returns the board the game is played on
+func (g *Game) Board() *board.Board {
+	return g.board
+}
+
+// Players returns the players of the game in turn order
+func (g *Game) Players() []player.Player {
+	return g.players
+}
+
+// CurrentPlayer returns the player whose turn it is
+func (g *Game) CurrentPlayer() player.Player {
+	return g.players[g.playerIdx]
+}
+
+// Status returns whether the game is still in progress, won, drawn or lost
+func (g *Game) Status() Status {
+	return g.status
+}
+
+// Winner returns the player that has won the game, or nil when nobody has won
+func (g *Game) Winner() player.Player {
+	return g.winner
+}
+
+// Loser returns the player that has lost the game by completing a line under misere rules, or nil when nobody has
+func (g *Game) Loser() player.Player {
+	return g.loser
+}
+
+// WinningLine returns the run of boxes that ended the game, it has no positions when no line was completed
+// under misere rules this is the line that lost the game
+func (g *Game) WinningLine() board.WinningLine {
+	return g.winningLine
+}
+
+// Play fills a box with the current player's symbol, then checks if the move has ended the game and otherwise passes the turn to the next player
+// position is the numbered box shown on the board, or the numbered column to drop the symbol into when the board has gravity
+func (g *Game) Play(position int) error {
+	if g.status != InProgress {
+		return ErrGameOver
+	}
+
+	currentPlayer := g.CurrentPlayer()
+
+	var rowIdx, colIdx int
+	if g.board.Gravity {
+		colIdx = position - 1
+
+		dropPieceParams := board.DropPieceParams{
+			ColIdx:  colIdx,
+			Content: currentPlayer.GetSymbol(),
+		}
+
+		var err error
+		if rowIdx, err = g.board.DropPiece(dropPieceParams); err != nil {
+			return err
+		}
+	} else {
+		if position < 1 || position > g.board.Rows()*g.board.Cols() {
+			return board.ErrOutOfBounds
+		}
+
+		rowIdx, colIdx = g.boxToIdx(position)
+
+		insertBoxWithContentParams := board.InsertBoxWithContentParams{
+			RowIdx:  rowIdx,
+			ColIdx:  colIdx,
+			Content: currentPlayer.GetSymbol(),
+		}
+
+		if err := g.board.SelectBox(insertBoxWithContentParams); err != nil {
+			return err
+		}
+	}
+
+	g.endTurn(rowIdx, colIdx)
+
+	return nil
+}
+
+// Undo takes back the last move made and gives the turn back to the player who made it
+func (g *Game) Undo() error {
+	if _, err := g.board.Undo(); err != nil {
+		return err
+	}
+	g.availableMoves++
+
+	// the turn is not passed on by a move that ends the game, so it already belongs to the player who made it
+	if g.status == InProgress {
+		g.playerIdx = (g.playerIdx - 1 + len(g.players)) % len(g.players)
+	}
+
+	g.status = InProgress
+	g.winner = nil
+	g.loser = nil
+	g.winningLine = board.WinningLine{}
+
+	return nil
+}
+
+// Redo plays the last move taken back by Undo again
+func (g *Game) Redo() error {
+	if g.status != InProgress {
+		return ErrGameOver
+	}
+
+	move, err := g.board.Redo()
+	if err != nil {
+		return err
+	}
+
+	g.endTurn(move.RowIdx, move.ColIdx)
+
+	return nil
+}
+
+// endTurn checks if the current player's move into the box on a particular row and col index has ended the game and otherwise passes the turn to the next player
+func (g *Game) endTurn(rowIdx, colIdx int) {
+	currentPlayer := g.CurrentPlayer()
+	g.availableMoves--
+
+	checkForWinnerParams := board.CheckForWinnerParams{
+		PlayerSymbol: currentPlayer.GetSymbol(),
+		RowIdx:       rowIdx,
+		ColIdx:       colIdx,
+	}
+
+	// check if player's move has made him/her the winner, or the loser with misere rules
+	if winningLine, completed := g.board.FindWinningLine(checkForWinnerParams); completed {
+		g.status = Won
+		g.winningLine = winningLine
+
+		if !g.board.Misere {
+			g.winner = currentPlayer
+			return
+		}
+
+		// with only 2 players the loser's opponent is the winner, with more the others share the win
+		g.loser = currentPlayer
+		if len(g.players) == 2 {
+			g.winner = g.players[(g.playerIdx+1)%len(g.players)]
+		} else {
+			g.status = Lost
+		}
+		return
+	}
+
+	// game ends in a draw when all possible moves have been made, or as soon as no player can complete a line anymore
+	if g.availableMoves == 0 || !g.board.CanCompleteLine() {
+		g.status = Draw
+		return
+	}
+
+	// switch to next player
+	g.playerIdx = (g.playerIdx + 1) % len(g.players)
+}
+
+// boxToIdx converts the numbered position of a box, counted from 1 left to right and top to bottom, into its row and col index
+func (g *Game) boxToIdx(box int) (int, int) {
+	cols := g.board.Cols()
+
+	return (box - 1) / cols, (box - 1) % cols
+}
@@ -0,0 +1,540 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dev-amos/tictactoe/board"
+	"github.com/dev-amos/tictactoe/player"
+	"github.com/dev-amos/tictactoe/player/real"
+)
+
+var testPlayers = []player.Player{
+	real.NewPlayer(real.NewPlayerParams{Name: "first", Symbol: board.X}),
+	real.NewPlayer(real.NewPlayerParams{Name: "second", Symbol: board.O}),
+}
+
+func TestNewGame(t *testing.T) {
+	type args struct {
+		noBoard bool
+		players []player.Player
+	}
+
+	type want struct {
+		err error
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"returns error when board is missing",
+			args{
+				true,
+				testPlayers,
+			},
+			want{
+				ErrBoardNotSpecified,
+			},
+		},
+		{
+			"returns error when there is only 1 player",
+			args{
+				false,
+				testPlayers[:1],
+			},
+			want{
+				ErrNotEnoughPlayers,
+			},
+		},
+		{
+			"creates a game in progress with the first player to move",
+			args{
+				false,
+				testPlayers,
+			},
+			want{
+				nil,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			newGameParams := NewGameParams{
+				Players: test.args.players,
+			}
+			if !test.args.noBoard {
+				newGameParams.Board, _ = board.NewBoard(board.NewBoardParams{WinCount: 3, Rows: 3, Cols: 3})
+			}
+
+			g, err := NewGame(newGameParams)
+
+			if !reflect.DeepEqual(err, test.want.err) {
+				t.Errorf("unexpected error = %v, want %v", err, test.want.err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if g.Status() != InProgress {
+				t.Errorf("unexpected status = %v, want %v", g.Status(), InProgress)
+			}
+
+			if g.CurrentPlayer() != test.args.players[0] {
+				t.Errorf("unexpected current player = %v, want %v", g.CurrentPlayer(), test.args.players[0])
+			}
+
+		})
+	}
+
+}
+
+func TestPlay(t *testing.T) {
+	type args struct {
+		boxes []int
+	}
+
+	type want struct {
+		err           error
+		status        Status
+		winner        player.Player
+		currentPlayer player.Player
+		winningLine   board.WinningLine
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"passes the turn to the next player",
+			args{
+				[]int{5},
+			},
+			want{
+				nil,
+				InProgress,
+				nil,
+				testPlayers[1],
+				board.WinningLine{},
+			},
+		},
+		{
+			"ends the game when the first player fills a row",
+			args{
+				[]int{1, 4, 2, 5, 3},
+			},
+			want{
+				nil,
+				Won,
+				testPlayers[0],
+				testPlayers[0],
+				board.WinningLine{Direction: board.Row, Positions: []board.Position{{RowIdx: 0, ColIdx: 0}, {RowIdx: 0, ColIdx: 1}, {RowIdx: 0, ColIdx: 2}}},
+			},
+		},
+		{
+			"ends the game when the second player fills a column",
+			args{
+				[]int{1, 3, 2, 6, 4, 9},
+			},
+			want{
+				nil,
+				Won,
+				testPlayers[1],
+				testPlayers[1],
+				board.WinningLine{Direction: board.Column, Positions: []board.Position{{RowIdx: 0, ColIdx: 2}, {RowIdx: 1, ColIdx: 2}, {RowIdx: 2, ColIdx: 2}}},
+			},
+		},
+		{
+			"ends the game in a draw as soon as no line can be completed",
+			args{
+				[]int{1, 2, 3, 5, 4, 6, 8, 7},
+			},
+			want{
+				nil,
+				Draw,
+				nil,
+				testPlayers[1],
+				board.WinningLine{},
+			},
+		},
+		{
+			"returns error when playing the last box after an early draw",
+			args{
+				[]int{1, 2, 3, 5, 4, 6, 8, 7, 9},
+			},
+			want{
+				ErrGameOver,
+				Draw,
+				nil,
+				testPlayers[1],
+				board.WinningLine{},
+			},
+		},
+		{
+			"returns error and keeps the turn when the box is occupied",
+			args{
+				[]int{5, 5},
+			},
+			want{
+				board.ErrBoxOccupied,
+				InProgress,
+				nil,
+				testPlayers[1],
+				board.WinningLine{},
+			},
+		},
+		{
+			"returns error and keeps the turn when the box is not on the board",
+			args{
+				[]int{5, 10},
+			},
+			want{
+				board.ErrOutOfBounds,
+				InProgress,
+				nil,
+				testPlayers[1],
+				board.WinningLine{},
+			},
+		},
+		{
+			"returns error when the box number is 0",
+			args{
+				[]int{0},
+			},
+			want{
+				board.ErrOutOfBounds,
+				InProgress,
+				nil,
+				testPlayers[0],
+				board.WinningLine{},
+			},
+		},
+		{
+			"returns error when playing after the game has ended",
+			args{
+				[]int{1, 4, 2, 5, 3, 6},
+			},
+			want{
+				ErrGameOver,
+				Won,
+				testPlayers[0],
+				testPlayers[0],
+				board.WinningLine{Direction: board.Row, Positions: []board.Position{{RowIdx: 0, ColIdx: 0}, {RowIdx: 0, ColIdx: 1}, {RowIdx: 0, ColIdx: 2}}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, _ := board.NewBoard(board.NewBoardParams{WinCount: 3, Rows: 3, Cols: 3})
+
+			newGameParams := NewGameParams{
+				Board:   b,
+				Players: testPlayers,
+			}
+			g, _ := NewGame(newGameParams)
+
+			var err error
+			for _, box := range test.args.boxes {
+				err = g.Play(box)
+			}
+
+			if !reflect.DeepEqual(err, test.want.err) {
+				t.Errorf("unexpected error = %v, want %v", err, test.want.err)
+			}
+
+			if g.Status() != test.want.status {
+				t.Errorf("unexpected status = %v, want %v", g.Status(), test.want.status)
+			}
+
+			if g.Winner() != test.want.winner {
+				t.Errorf("unexpected winner = %v, want %v", g.Winner(), test.want.winner)
+			}
+
+			if g.CurrentPlayer() != test.want.currentPlayer {
+				t.Errorf("unexpected current player = %v, want %v", g.CurrentPlayer(), test.want.currentPlayer)
+			}
+
+			if !reflect.DeepEqual(g.WinningLine(), test.want.winningLine) {
+				t.Errorf("unexpected winning line = %v, want %v", g.WinningLine(), test.want.winningLine)
+			}
+
+		})
+	}
+
+}
+
+func TestUndoRedo(t *testing.T) {
+	type args struct {
+		boxes []int
+		undos int
+		redos int
+	}
+
+	type want struct {
+		err           error
+		status        Status
+		currentPlayer player.Player
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"gives the turn back to the player who made the undone move",
+			args{
+				[]int{5, 1},
+				1,
+				0,
+			},
+			want{
+				nil,
+				InProgress,
+				testPlayers[1],
+			},
+		},
+		{
+			"reopens a won game and keeps the turn with the winner",
+			args{
+				[]int{1, 4, 2, 5, 3},
+				1,
+				0,
+			},
+			want{
+				nil,
+				InProgress,
+				testPlayers[0],
+			},
+		},
+		{
+			"ends the game again when redoing the winning move",
+			args{
+				[]int{1, 4, 2, 5, 3},
+				1,
+				1,
+			},
+			want{
+				nil,
+				Won,
+				testPlayers[0],
+			},
+		},
+		{
+			"returns error when there is no move to undo",
+			args{
+				[]int{},
+				1,
+				0,
+			},
+			want{
+				board.ErrNothingToUndo,
+				InProgress,
+				testPlayers[0],
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, _ := board.NewBoard(board.NewBoardParams{WinCount: 3, Rows: 3, Cols: 3})
+
+			newGameParams := NewGameParams{
+				Board:   b,
+				Players: testPlayers,
+			}
+			g, _ := NewGame(newGameParams)
+
+			for _, box := range test.args.boxes {
+				g.Play(box)
+			}
+
+			var err error
+			for i := 0; i < test.args.undos; i++ {
+				err = g.Undo()
+			}
+			for i := 0; i < test.args.redos; i++ {
+				err = g.Redo()
+			}
+
+			if !reflect.DeepEqual(err, test.want.err) {
+				t.Errorf("unexpected error = %v, want %v", err, test.want.err)
+			}
+
+			if g.Status() != test.want.status {
+				t.Errorf("unexpected status = %v, want %v", g.Status(), test.want.status)
+			}
+
+			if g.CurrentPlayer() != test.want.currentPlayer {
+				t.Errorf("unexpected current player = %v, want %v", g.CurrentPlayer(), test.want.currentPlayer)
+			}
+
+		})
+	}
+
+}
+
+func TestPlayWithGravity(t *testing.T) {
+	b, _ := board.NewBoard(board.NewBoardParams{WinCount: 3, Rows: 3, Cols: 3, Gravity: true})
+
+	newGameParams := NewGameParams{
+		Board:   b,
+		Players: testPlayers,
+	}
+	g, _ := NewGame(newGameParams)
+
+	// both players stack their symbols in the first 2 columns until the first player completes the bottom row
+	for _, column := range []int{1, 1, 2, 2, 3} {
+		if err := g.Play(column); err != nil {
+			t.Fatalf("unexpected error = %v, want %v", err, nil)
+		}
+	}
+
+	if g.Status() != Won || g.Winner() != testPlayers[0] {
+		t.Errorf("unexpected result = %v by %v, want %v by %v", g.Status(), g.Winner(), Won, testPlayers[0])
+	}
+
+	wantBoxes := [][]board.BoxContent{
+		{board.E, board.E, board.E},
+		{board.O, board.O, board.E},
+		{board.X, board.X, board.X},
+	}
+	if !reflect.DeepEqual(b.Boxes, wantBoxes) {
+		t.Errorf("unexpected Boxes = %v, want %v", b.Boxes, wantBoxes)
+	}
+
+}
+
+func TestPlayWithMisere(t *testing.T) {
+	b, _ := board.NewBoard(board.NewBoardParams{WinCount: 3, Rows: 3, Cols: 3, Misere: true})
+
+	newGameParams := NewGameParams{
+		Board:   b,
+		Players: testPlayers,
+	}
+	g, _ := NewGame(newGameParams)
+
+	for _, box := range []int{1, 4, 2, 5, 3} {
+		if err := g.Play(box); err != nil {
+			t.Fatalf("unexpected error = %v, want %v", err, nil)
+		}
+	}
+
+	if g.Status() != Won {
+		t.Errorf("unexpected status = %v, want %v", g.Status(), Won)
+	}
+
+	if g.Loser() != testPlayers[0] {
+		t.Errorf("unexpected loser = %v, want %v", g.Loser(), testPlayers[0])
+	}
+
+	if g.Winner() != testPlayers[1] {
+		t.Errorf("unexpected winner = %v, want %v", g.Winner(), testPlayers[1])
+	}
+
+}
+
+func TestPlayWithMorePlayers(t *testing.T) {
+	threePlayers := append(testPlayers, real.NewPlayer(real.NewPlayerParams{Name: "third", Symbol: board.T}))
+
+	type args struct {
+		misere bool
+		boxes  []int
+	}
+
+	type want struct {
+		status        Status
+		winner        player.Player
+		loser         player.Player
+		currentPlayer player.Player
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"passes the turn from the last player back to the first",
+			args{
+				false,
+				[]int{1, 2, 3},
+			},
+			want{
+				InProgress,
+				nil,
+				nil,
+				threePlayers[0],
+			},
+		},
+		{
+			"ends the game when the third player fills a column",
+			args{
+				false,
+				[]int{1, 2, 3, 4, 6, 7, 16, 13, 11},
+			},
+			want{
+				Won,
+				threePlayers[2],
+				nil,
+				threePlayers[2],
+			},
+		},
+		{
+			"ends the game with a loser and no single winner with misere rules",
+			args{
+				true,
+				[]int{1, 2, 3, 4, 6, 7, 16, 13, 11},
+			},
+			want{
+				Lost,
+				nil,
+				threePlayers[2],
+				threePlayers[2],
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, _ := board.NewBoard(board.NewBoardParams{WinCount: 3, Rows: 4, Cols: 4, Misere: test.args.misere})
+
+			newGameParams := NewGameParams{
+				Board:   b,
+				Players: threePlayers,
+			}
+			g, _ := NewGame(newGameParams)
+
+			for _, box := range test.args.boxes {
+				if err := g.Play(box); err != nil {
+					t.Fatalf("unexpected error = %v, want %v", err, nil)
+				}
+			}
+
+			if g.Status() != test.want.status {
+				t.Errorf("unexpected status = %v, want %v", g.Status(), test.want.status)
+			}
+
+			if g.Winner() != test.want.winner {
+				t.Errorf("unexpected winner = %v, want %v", g.Winner(), test.want.winner)
+			}
+
+			if g.Loser() != test.want.loser {
+				t.Errorf("unexpected loser = %v, want %v", g.Loser(), test.want.loser)
+			}
+
+			if g.CurrentPlayer() != test.want.currentPlayer {
+				t.Errorf("unexpected current player = %v, want %v", g.CurrentPlayer(), test.want.currentPlayer)
+			}
+
+		})
+	}
+
+}
@@ -0,0 +1,456 @@
+// Package ai contains a computer-controlled tic tac toe player
+package ai
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/dev-amos/tictactoe/board"
+	"github.com/dev-amos/tictactoe/player"
+)
+
+var (
+	ErrNoBoxAvailable = errors.New("no empty box is left on the board to choose from")
+)
+
+// nodeCheckInterval is the number of moves tried between checks of whether the context of a search is done
+const nodeCheckInterval = 256
+
+// turnKeys are mixed into the hash of the board to tell apart the same boxes with different players to move, for up to four players
+var turnKeys = [...]uint64{0, 0x9e3779b97f4a7c15, 0xbf58476d1ce4e5b9, 0x94d049bb133111eb}
+
+// winScore is the score of a won position, it is reduced by the number of moves needed to reach the win so that faster wins are preferred
+const winScore = 1 << 20
+
+type computerPlayer struct {
+	name      string
+	symbol    board.BoxContent
+	opponents []board.BoxContent
+	maxDepth  int
+	level     player.Level
+	blunder   float64
+	workers   int
+	rng       *rand.Rand
+}
+
+// NewPlayerParams defines the structure for the parameters needed to create a computer-controlled player
+type NewPlayerParams struct {
+	Name   string
+	Symbol board.BoxContent
+	// Opponents are the symbols of the other players in the order they move after this player
+	Opponents []board.BoxContent
+	// MaxDepth caps the number of moves looked ahead, 0 searches until the end of the game or until the context given to ChooseBox is done
+	MaxDepth int
+	// Level is the level the player is created to play at, it is only reported back by GetLevel
+	Level player.Level
+	// Blunder is the chance, from 0 to 1, of filling a random box instead of the box searched for
+	Blunder float64
+	// Seed makes the random boxes filled by blunders the same every time, 0 picks a seed from the current time
+	Seed int64
+	// Workers is the number of goroutines searching for every move at the same time, 0 starts one for every CPU the program can use
+	Workers int
+}
+
+// NewPlayer creates a computer-controlled player.
+func NewPlayer(params NewPlayerParams) player.Computer {
+	seed := params.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	workers := params.Workers
+	if workers == 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	return computerPlayer{
+		name:      params.Name,
+		symbol:    params.Symbol,
+		opponents: params.Opponents,
+		maxDepth:  params.MaxDepth,
+		level:     params.Level,
+		blunder:   params.Blunder,
+		workers:   workers,
+		rng:       rand.New(rand.NewSource(seed)),
+	}
+}
+
+// GetName returns name of player.
+func (cp computerPlayer) GetName() string {
+	return cp.name
+}
+
+// GetSymbol returns symbol used by player to fill the boxes in tic tac toe
+func (cp computerPlayer) GetSymbol() board.BoxContent {
+	return cp.symbol
+}
+
+// GetLevel returns the level the player was created to play at
+func (cp computerPlayer) GetLevel() player.Level {
+	return cp.level
+}
+
+// ChooseBox searches the board with minimax and alpha-beta pruning and returns the numbered position of the best box to fill
+// on a board with gravity the numbered column to drop the symbol into is returned instead
+// with more than one opponent every opponent is assumed to play against this player
+// the search looks one move further ahead at a time, and when the context is done it returns the best box of the deepest search it finished
+// the boxes are shared out between the workers, which remember the positions they have searched in a transposition table they all share
+func (cp computerPlayer) ChooseBox(ctx context.Context, b *board.Board) (int, error) {
+
+	s := search{
+		board:     *b.Clone(),
+		turnOrder: append([]board.BoxContent{cp.symbol}, cp.opponents...),
+		order:     board.OrderByCentre(b.Rows(), b.Cols()),
+		workers:   cp.workers,
+		table:     newTable(),
+	}
+
+	empty := s.emptyCount()
+	if empty == 0 {
+		return 0, ErrNoBoxAvailable
+	}
+
+	best := s.deepen(ctx, cp.maxDepth, empty)
+	if cp.blunder > 0 && cp.rng.Float64() < cp.blunder {
+		best = s.randomBox(cp.rng)
+	}
+
+	// with gravity the player only chooses the column and the piece falls to the box that was searched
+	if b.Gravity {
+		return best.ColIdx + 1, nil
+	}
+
+	return best.RowIdx*b.Cols() + best.ColIdx + 1, nil
+}
+
+// deepen searches one move deeper at a time until the depth limit or the end of the game is reached, or the context is done,
+// and returns the best box of the deepest search that was finished
+func (s *search) deepen(ctx context.Context, maxDepth, empty int) board.Position {
+	limit := empty
+	if maxDepth > 0 && maxDepth < limit {
+		limit = maxDepth
+	}
+
+	var best board.Position
+	for depth := 1; depth <= limit; depth++ {
+		// the search one move deep is never stopped, so that there is a box to return however little time is left
+		s.ctx = context.Background()
+		if depth > 1 {
+			if ctx.Err() != nil {
+				break
+			}
+			s.ctx = ctx
+		}
+
+		s.maxDepth = depth
+		box, score, finished := s.bestBox()
+		if !finished {
+			break
+		}
+		best = box
+		s.moveToFront(best)
+
+		// a game found to be won or lost within the depth ends the same way however much deeper the search looks
+		if score > board.MaxEvaluation || score < -board.MaxEvaluation {
+			break
+		}
+	}
+
+	return best
+}
+
+// bestBox returns the box with the best score for the searching player and its score
+// the first box is searched on its own to find a score the other boxes have to beat, then the other boxes are shared out between the workers,
+// each searching on its own clone of the board with the best score found so far by any of them
+// it also returns whether the search was finished, a search stopped because its context is done returns no box
+func (s *search) bestBox() (board.Position, int, bool) {
+	empty := s.emptyCount()
+
+	var boxes []board.Position
+	for _, pos := range s.order {
+		if s.board.IsPlayable(pos.RowIdx, pos.ColIdx) {
+			boxes = append(boxes, pos)
+		}
+	}
+
+	beta := winScore + 1
+	bestScore := s.scoreMove(boxes[0], 0, 1, -winScore-1, beta, empty)
+	if s.stopped {
+		return board.Position{}, 0, false
+	}
+	best := 0
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	next := 1
+	stopped := false
+
+	for i := 0; i < s.workers && i < len(boxes)-1; i++ {
+		worker := s.fork()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				mu.Lock()
+				if next == len(boxes) || stopped {
+					mu.Unlock()
+					return
+				}
+				idx := next
+				next++
+
+				// alpha is kept one below the best score so that a box scoring the same gets its real score, and of the boxes scoring the best
+				// the first one in the search order is chosen however the boxes were shared out
+				alpha := bestScore - 1
+				mu.Unlock()
+
+				score := worker.scoreMove(boxes[idx], 0, 1, alpha, beta, empty)
+
+				mu.Lock()
+				if worker.stopped {
+					stopped = true
+				} else if score > bestScore || (score == bestScore && idx < best) {
+					bestScore = score
+					best = idx
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	if stopped {
+		return board.Position{}, 0, false
+	}
+
+	return boxes[best], bestScore, true
+}
+
+// fork returns a search sharing the transposition table and the search order, with its own clone of the board so that it can be run in another goroutine
+func (s *search) fork() *search {
+	return &search{
+		board:     *s.board.Clone(),
+		turnOrder: s.turnOrder,
+		maxDepth:  s.maxDepth,
+		order:     s.order,
+		workers:   1,
+		table:     s.table,
+		ctx:       s.ctx,
+	}
+}
+
+// moveToFront moves the position to the front of the search order, keeping the order of the other positions
+// the best box of a search is tried first by the next, deeper search so that alpha-beta prunes more of it
+func (s *search) moveToFront(pos board.Position) {
+	for i := range s.order {
+		if s.order[i] == pos {
+			copy(s.order[1:i+1], s.order[:i])
+			s.order[0] = pos
+			return
+		}
+	}
+}
+
+// randomBox returns a random box out of the boxes that can be filled by the next move
+func (s *search) randomBox(rng *rand.Rand) board.Position {
+	var playable []board.Position
+	for _, pos := range s.order {
+		if s.board.IsPlayable(pos.RowIdx, pos.ColIdx) {
+			playable = append(playable, pos)
+		}
+	}
+
+	return playable[rng.Intn(len(playable))]
+}
+
+// search holds the state shared by every node of a single game tree search
+type search struct {
+	board     board.Board
+	turnOrder []board.BoxContent // symbols in the order they move, starting with the searching player
+	maxDepth  int
+	order     []board.Position // read by every worker, so it is only changed between searches
+	workers   int
+	table     *table
+	evaluator board.Evaluator // scores boards at the depth limit, every worker has its own
+	ctx       context.Context // stops the search when it is done
+	nodes     int             // moves tried, the context is checked every nodeCheckInterval of them
+	stopped   bool            // the context was found done, every score returned after it is meaningless
+}
+
+// scoreMove fills the box at pos with the symbol of the player whose turn it is, scores the resulting position and takes the move back again
+// scores are always from the point of view of the searching player
+func (s *search) scoreMove(pos board.Position, turn, ply, alpha, beta, empty int) int {
+	s.nodes++
+	if s.nodes%nodeCheckInterval == 0 && s.ctx.Err() != nil {
+		s.stopped = true
+	}
+	if s.stopped {
+		return 0
+	}
+
+	insertBoxWithContentParams := board.InsertBoxWithContentParams{
+		RowIdx:  pos.RowIdx,
+		ColIdx:  pos.ColIdx,
+		Content: s.turnOrder[turn],
+	}
+
+	s.board.SelectBox(insertBoxWithContentParams)
+	defer s.board.Undo()
+
+	checkForWinnerParams := board.CheckForWinnerParams{
+		PlayerSymbol: s.turnOrder[turn],
+		RowIdx:       pos.RowIdx,
+		ColIdx:       pos.ColIdx,
+	}
+
+	// completing a line wins the game, or loses it with misere rules
+	if s.board.CheckForWinner(checkForWinnerParams) {
+		if (turn == 0) != s.board.Misere {
+			return winScore - ply
+		}
+		return -(winScore - ply)
+	}
+
+	// a full board is a draw
+	if empty == 1 {
+		return 0
+	}
+
+	// at the depth limit the board is scored without searching further, evaluations always stay below the score of a won position
+	if s.maxDepth > 0 && ply >= s.maxDepth {
+		return s.evaluator.Evaluate(&s.board, s.turnOrder[0])
+	}
+
+	return s.minimax((turn+1)%len(s.turnOrder), ply+1, alpha, beta, empty-1)
+}
+
+// minimax returns the best score the player whose turn it is can achieve from the current position
+// the searching player maximises the score while every opponent minimises it
+// the score is exact when it falls between alpha and beta and a bound otherwise
+func (s *search) minimax(turn, ply, alpha, beta, empty int) int {
+	depth := s.maxDepth - ply + 1
+	if depth > empty {
+		depth = empty
+	}
+
+	key := s.board.Hash() ^ turnKeys[turn]
+	stored, found := s.table.load(key)
+	if found && stored.depth >= depth {
+		score := fromTable(stored.score, ply)
+		switch {
+		case stored.bound == exact:
+			return score
+		case stored.bound == lowerBound && score >= beta:
+			return score
+		case stored.bound == upperBound && score <= alpha:
+			return score
+		}
+	}
+
+	maximising := turn == 0
+	originalAlpha := alpha
+	originalBeta := beta
+
+	best := winScore + 1
+	if maximising {
+		best = -winScore - 1
+	}
+	var bestPosition board.Position
+
+	// the best box found the last time the position was searched is tried first, before the boxes in their usual order
+	for i := -1; i < len(s.order); i++ {
+		var pos board.Position
+		if i < 0 && !found {
+			continue
+		} else if i < 0 {
+			pos = stored.best
+		} else if pos = s.order[i]; found && pos == stored.best {
+			continue
+		}
+
+		if !s.board.IsPlayable(pos.RowIdx, pos.ColIdx) {
+			continue
+		}
+
+		score := s.scoreMove(pos, turn, ply, alpha, beta, empty)
+		if maximising {
+			if score > best {
+				best = score
+				bestPosition = pos
+			}
+			if score > alpha {
+				alpha = score
+			}
+		} else {
+			if score < best {
+				best = score
+				bestPosition = pos
+			}
+			if score < beta {
+				beta = score
+			}
+		}
+
+		if alpha >= beta || s.stopped {
+			break
+		}
+	}
+
+	// a search stopped part way has not scored the position
+	if s.stopped {
+		return best
+	}
+
+	bound := exact
+	if best <= originalAlpha {
+		bound = upperBound
+	} else if best >= originalBeta {
+		bound = lowerBound
+	}
+	s.table.store(key, entry{depth, toTable(best, ply), bound, bestPosition})
+
+	return best
+}
+
+// toTable returns the score to store in the transposition table for a position searched at the ply,
+// won and lost scores count the moves from the root and are stored counting the moves from the position instead
+func toTable(score, ply int) int {
+	if score > board.MaxEvaluation {
+		return score + ply - 1
+	} else if score < -board.MaxEvaluation {
+		return score - ply + 1
+	}
+
+	return score
+}
+
+// fromTable returns the score of a position searched at the ply out of the score stored for it in the transposition table, it undoes toTable
+func fromTable(score, ply int) int {
+	if score > board.MaxEvaluation {
+		return score - ply + 1
+	} else if score < -board.MaxEvaluation {
+		return score + ply - 1
+	}
+
+	return score
+}
+
+// emptyCount returns the number of boxes that are still empty
+func (s *search) emptyCount() int {
+	count := 0
+	for row := range s.board.Boxes {
+		for col := range s.board.Boxes[row] {
+			if s.board.Boxes[row][col] == board.E {
+				count++
+			}
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,415 @@
+package ai
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dev-amos/tictactoe/board"
+)
+
+func TestChooseBox(t *testing.T) {
+	type args struct {
+		winCount int
+		gravity  bool
+		boxes    [][]board.BoxContent
+		maxDepth int
+	}
+
+	type want struct {
+		err error
+		box int
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"completes its own row instead of blocking",
+			args{
+				3,
+				false,
+				[][]board.BoxContent{
+					{board.X, board.X, board.E},
+					{board.O, board.O, board.E},
+					{board.E, board.E, board.E},
+				},
+				0,
+			},
+			want{
+				nil,
+				3,
+			},
+		},
+		{
+			"blocks the opponent's diagonal",
+			args{
+				3,
+				false,
+				[][]board.BoxContent{
+					{board.O, board.E, board.E},
+					{board.E, board.O, board.E},
+					{board.X, board.E, board.E},
+				},
+				0,
+			},
+			want{
+				nil,
+				9,
+			},
+		},
+		{
+			"takes the centre on an empty board",
+			args{
+				3,
+				false,
+				[][]board.BoxContent{
+					{board.E, board.E, board.E},
+					{board.E, board.E, board.E},
+					{board.E, board.E, board.E},
+				},
+				0,
+			},
+			want{
+				nil,
+				5,
+			},
+		},
+		{
+			"completes a line of 4 on a 5*5 board with a depth limit",
+			args{
+				4,
+				false,
+				[][]board.BoxContent{
+					{board.O, board.E, board.E, board.E, board.E},
+					{board.E, board.X, board.O, board.E, board.E},
+					{board.E, board.O, board.X, board.E, board.E},
+					{board.E, board.O, board.E, board.X, board.E},
+					{board.E, board.E, board.E, board.E, board.E},
+				},
+				2,
+			},
+			want{
+				nil,
+				25,
+			},
+		},
+		{
+			"numbers boxes row by row on a 2*4 board",
+			args{
+				3,
+				false,
+				[][]board.BoxContent{
+					{board.O, board.O, board.E, board.E},
+					{board.X, board.X, board.E, board.E},
+				},
+				0,
+			},
+			want{
+				nil,
+				7,
+			},
+		},
+		{
+			"returns the column to block four in a row with gravity",
+			args{
+				4,
+				true,
+				[][]board.BoxContent{
+					{board.E, board.E, board.E, board.E, board.E},
+					{board.E, board.E, board.E, board.E, board.E},
+					{board.E, board.E, board.E, board.E, board.E},
+					{board.X, board.E, board.E, board.E, board.E},
+					{board.X, board.O, board.O, board.O, board.E},
+				},
+				4,
+			},
+			want{
+				nil,
+				5,
+			},
+		},
+		{
+			"returns error when the board is full",
+			args{
+				3,
+				false,
+				[][]board.BoxContent{
+					{board.X, board.O, board.X},
+					{board.X, board.O, board.O},
+					{board.O, board.X, board.X},
+				},
+				0,
+			},
+			want{
+				ErrNoBoxAvailable,
+				0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			newBoardParams := board.NewBoardParams{
+				WinCount: test.args.winCount,
+				Rows:     len(test.args.boxes),
+				Cols:     len(test.args.boxes[0]),
+				Gravity:  test.args.gravity,
+			}
+			b, _ := board.NewBoard(newBoardParams)
+			b.Boxes = test.args.boxes
+
+			newPlayerParams := NewPlayerParams{
+				Name:      "computer",
+				Symbol:    board.X,
+				Opponents: []board.BoxContent{board.O},
+				MaxDepth:  test.args.maxDepth,
+			}
+			p := NewPlayer(newPlayerParams)
+
+			gotBox, err := p.ChooseBox(context.Background(), b)
+
+			if !reflect.DeepEqual(err, test.want.err) {
+				t.Errorf("unexpected error = %v, want %v", err, test.want.err)
+			}
+
+			if gotBox != test.want.box {
+				t.Errorf("unexpected box = %d, want %d", gotBox, test.want.box)
+			}
+
+		})
+	}
+
+}
+
+func TestChooseBoxWithMisere(t *testing.T) {
+	newBoardParams := board.NewBoardParams{
+		WinCount: 3,
+		Rows:     3,
+		Cols:     3,
+		Misere:   true,
+	}
+	b, _ := board.NewBoard(newBoardParams)
+	b.Boxes = [][]board.BoxContent{
+		{board.X, board.X, board.E},
+		{board.O, board.O, board.E},
+		{board.X, board.O, board.E},
+	}
+
+	newPlayerParams := NewPlayerParams{
+		Name:      "computer",
+		Symbol:    board.X,
+		Opponents: []board.BoxContent{board.O},
+	}
+	p := NewPlayer(newPlayerParams)
+
+	// box 3 completes the top row and box 6 leaves the opponent a safe move that forces box 3, only box 9 avoids losing
+	gotBox, err := p.ChooseBox(context.Background(), b)
+
+	if err != nil {
+		t.Errorf("unexpected error = %v, want %v", err, nil)
+	}
+
+	if gotBox != 9 {
+		t.Errorf("unexpected box = %d, want %d", gotBox, 9)
+	}
+
+}
+
+func TestChooseBoxWithMoreOpponents(t *testing.T) {
+	newBoardParams := board.NewBoardParams{
+		WinCount: 3,
+		Rows:     4,
+		Cols:     4,
+	}
+	b, _ := board.NewBoard(newBoardParams)
+	b.Boxes = [][]board.BoxContent{
+		{board.X, board.E, board.E, board.E},
+		{board.E, board.E, board.E, board.E},
+		{board.O, board.O, board.E, board.E},
+		{board.E, board.T, board.E, board.E},
+	}
+
+	newPlayerParams := NewPlayerParams{
+		Name:      "computer",
+		Symbol:    board.X,
+		Opponents: []board.BoxContent{board.O, board.T},
+		MaxDepth:  3,
+	}
+	p := NewPlayer(newPlayerParams)
+
+	// the opponent moving next completes the third row unless box 11 is taken
+	gotBox, err := p.ChooseBox(context.Background(), b)
+
+	if err != nil {
+		t.Errorf("unexpected error = %v, want %v", err, nil)
+	}
+
+	if gotBox != 11 {
+		t.Errorf("unexpected box = %d, want %d", gotBox, 11)
+	}
+
+}
+
+func TestChooseBoxWithBlunder(t *testing.T) {
+	newBoardParams := board.NewBoardParams{
+		WinCount: 3,
+		Rows:     3,
+		Cols:     3,
+	}
+	b, _ := board.NewBoard(newBoardParams)
+	b.Boxes = [][]board.BoxContent{
+		{board.X, board.X, board.E},
+		{board.O, board.O, board.E},
+		{board.E, board.E, board.E},
+	}
+
+	newPlayerParams := NewPlayerParams{
+		Name:      "computer",
+		Symbol:    board.X,
+		Opponents: []board.BoxContent{board.O},
+		Blunder:   1,
+		Seed:      1,
+	}
+	p := NewPlayer(newPlayerParams)
+
+	// a player that always blunders fills random empty boxes instead of always completing the top row with box 3
+	chosen := make(map[int]bool)
+	for i := 0; i < 20; i++ {
+		gotBox, err := p.ChooseBox(context.Background(), b)
+		if err != nil {
+			t.Fatalf("unexpected error = %v, want %v", err, nil)
+		}
+
+		if content := b.Boxes[(gotBox-1)/3][(gotBox-1)%3]; content != board.E {
+			t.Errorf("unexpected box = %d holding %v, want an empty box", gotBox, content)
+		}
+		chosen[gotBox] = true
+	}
+
+	if len(chosen) < 2 {
+		t.Errorf("unexpected boxes chosen = %v, want more than one box", chosen)
+	}
+
+}
+
+func TestChooseBoxWithDeadline(t *testing.T) {
+	type args struct {
+		position string
+		timeout  time.Duration
+	}
+
+	type want struct {
+		boxes  []int // any of the boxes is right, every empty box when there are none
+		within time.Duration
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"completes its own line with the search one move deep when the deadline has already passed",
+			args{"10x10 5 10/10/10/2xxxx4/2oooo4/10/10/10/10/10 x", 0},
+			want{[]int{32, 37}, time.Second},
+		},
+		{
+			"returns the best box found once the deadline has passed on a board far too big to search until the end of the game",
+			args{"10x10 5 10/10/10/10/10/10/10/10/10/10 x", 100 * time.Millisecond},
+			want{nil, time.Second},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, _, err := board.ParsePosition(test.args.position)
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			newPlayerParams := NewPlayerParams{
+				Name:      "computer",
+				Symbol:    board.X,
+				Opponents: []board.BoxContent{board.O},
+			}
+			p := NewPlayer(newPlayerParams)
+
+			ctx, cancel := context.WithTimeout(context.Background(), test.args.timeout)
+			defer cancel()
+
+			start := time.Now()
+			gotBox, err := p.ChooseBox(ctx, b)
+
+			if err != nil {
+				t.Errorf("unexpected error = %v, want %v", err, nil)
+			}
+
+			found := test.want.boxes == nil && gotBox >= 1 && gotBox <= b.Rows()*b.Cols()
+			for _, box := range test.want.boxes {
+				found = found || gotBox == box
+			}
+			if !found {
+				t.Errorf("unexpected box = %d, want one of %v", gotBox, test.want.boxes)
+			}
+
+			if elapsed := time.Since(start); elapsed > test.want.within {
+				t.Errorf("unexpected time taken = %v, want less than %v", elapsed, test.want.within)
+			}
+
+		})
+	}
+
+}
+
+func TestChooseBoxWithWorkers(t *testing.T) {
+	tests := []struct {
+		name     string
+		position string
+		box      int
+	}{
+		{
+			"completes its own row",
+			"7x7 4 7/7/oxxx3/1ooo3/7/7/7 x",
+			19,
+		},
+		{
+			"blocks the row of the opponent",
+			"7x7 4 7/7/7/xooo3/x6/7/7 x",
+			26,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, _, err := board.ParsePosition(test.position)
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			// the workers share out the boxes and the transposition table, each searching on its own clone of the board
+			newPlayerParams := NewPlayerParams{
+				Name:      "computer",
+				Symbol:    board.X,
+				Opponents: []board.BoxContent{board.O},
+				MaxDepth:  4,
+				Workers:   4,
+			}
+			p := NewPlayer(newPlayerParams)
+
+			gotBox, err := p.ChooseBox(context.Background(), b)
+			if err != nil {
+				t.Errorf("unexpected error = %v, want %v", err, nil)
+			}
+
+			if gotBox != test.box {
+				t.Errorf("unexpected box = %d, want %d", gotBox, test.box)
+			}
+
+		})
+	}
+
+}
@@ -0,0 +1,70 @@
+package ai
+
+import (
+	"sync"
+
+	"github.com/dev-amos/tictactoe/board"
+)
+
+// tableShards is the number of parts the transposition table is split into, each behind its own lock so that goroutines searching at the same time seldom wait for each other
+const tableShards = 64
+
+// boundType tells how a score stored in the transposition table relates to the real score of the position
+type boundType int
+
+const (
+	exact      boundType = iota // Score is the real score
+	lowerBound                  // Real score is at least the score, the search was cut off by beta
+	upperBound                  // Real score is at most the score, the search was cut off by alpha
+)
+
+// entry is a position stored in the transposition table
+type entry struct {
+	depth int // number of moves searched ahead from the position, the full board is searched when it covers every empty box
+	score int // score for the searching player, won and lost scores count the moves from the position instead of from the root
+	bound boundType
+	best  board.Position
+}
+
+// table is a transposition table shared by every goroutine searching for the same move, it is safe for concurrent use
+type table struct {
+	shards [tableShards]tableShard
+}
+
+// tableShard is the part of the table holding the keys that leave the same remainder when divided by tableShards
+type tableShard struct {
+	mu      sync.Mutex
+	entries map[uint64]entry
+}
+
+// newTable creates an empty transposition table
+func newTable() *table {
+	t := &table{}
+	for i := range t.shards {
+		t.shards[i].entries = make(map[uint64]entry)
+	}
+
+	return t
+}
+
+// load returns the entry stored for the key and whether there is one
+func (t *table) load(key uint64) (entry, bool) {
+	shard := &t.shards[key%tableShards]
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	e, found := shard.entries[key]
+	return e, found
+}
+
+// store stores the entry for the key, unless the entry already stored was searched further ahead
+func (t *table) store(key uint64, e entry) {
+	shard := &t.shards[key%tableShards]
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	if stored, found := shard.entries[key]; found && stored.depth > e.depth {
+		return
+	}
+	shard.entries[key] = e
+}
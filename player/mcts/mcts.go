@@ -0,0 +1,371 @@
+// Package mcts contains a computer-controlled tic tac toe player that searches with monte carlo tree search, for boards too big to search exhaustively
+package mcts
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/dev-amos/tictactoe/board"
+	"github.com/dev-amos/tictactoe/player"
+)
+
+var (
+	ErrNoBoxAvailable = errors.New("no empty box is left on the board to choose from")
+)
+
+// defaultIterations is the number of playouts run for every move when no iteration budget is given and the context given to ChooseBox is never done
+const defaultIterations = 10000
+
+// exploration weighs how much UCT favours moves that have been tried less often over moves that have scored well so far
+var exploration = math.Sqrt2
+
+type computerPlayer struct {
+	name       string
+	symbol     board.BoxContent
+	opponents  []board.BoxContent
+	iterations int
+	level      player.Level
+	rng        *rand.Rand
+}
+
+// NewPlayerParams defines the structure for the parameters needed to create a computer-controlled player searching with monte carlo tree search
+type NewPlayerParams struct {
+	Name   string
+	Symbol board.BoxContent
+	// Opponents are the symbols of the other players in the order they move after this player
+	Opponents []board.BoxContent
+	// Iterations caps the number of playouts run for every move, 0 runs playouts until the context given to ChooseBox is done
+	Iterations int
+	// Seed makes the moves chosen the same every time for the same iteration budget, 0 picks a seed from the current time
+	Seed int64
+	// Level is the level the player is created to play at, it is only reported back by GetLevel
+	Level player.Level
+}
+
+// NewPlayer creates a computer-controlled player searching with monte carlo tree search.
+func NewPlayer(params NewPlayerParams) player.Computer {
+	seed := params.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	return computerPlayer{
+		name:       params.Name,
+		symbol:     params.Symbol,
+		opponents:  params.Opponents,
+		iterations: params.Iterations,
+		level:      params.Level,
+		rng:        rand.New(rand.NewSource(seed)),
+	}
+}
+
+// GetName returns name of player.
+func (cp computerPlayer) GetName() string {
+	return cp.name
+}
+
+// GetSymbol returns symbol used by player to fill the boxes in tic tac toe
+func (cp computerPlayer) GetSymbol() board.BoxContent {
+	return cp.symbol
+}
+
+// GetLevel returns the level the player was created to play at
+func (cp computerPlayer) GetLevel() player.Level {
+	return cp.level
+}
+
+// ChooseBox grows a search tree with UCT and random playouts until the iteration budget runs out or the context is done,
+// and returns the numbered position of the box tried most often
+// on a board with gravity the numbered column to drop the symbol into is returned instead
+func (cp computerPlayer) ChooseBox(ctx context.Context, b *board.Board) (int, error) {
+
+	s := search{
+		board:     *b.Clone(),
+		turnOrder: append([]board.BoxContent{cp.symbol}, cp.opponents...),
+		rng:       cp.rng,
+	}
+
+	// the player who moved into the root is the last player in turn order, so that its children are moves of this player
+	root := s.newNode(nil, board.Position{}, s.turnOrder[len(s.turnOrder)-1], 0)
+	if len(root.untried) == 0 {
+		return 0, ErrNoBoxAvailable
+	}
+
+	// a context that is never done cannot stop the search, so it is stopped after the default number of playouts instead
+	iterations := cp.iterations
+	if iterations == 0 && ctx.Done() == nil {
+		iterations = defaultIterations
+	}
+
+	// at least one iteration is run so that there is a move to choose from, however small the budget
+	for i := 0; iterations == 0 || i < iterations; i++ {
+		s.iterate(root)
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	best := root.children[0]
+	for _, child := range root.children[1:] {
+		if child.visits > best.visits {
+			best = child
+		}
+	}
+
+	// with gravity the player only chooses the column and the piece falls to the box that was searched
+	if b.Gravity {
+		return best.move.ColIdx + 1, nil
+	}
+
+	return best.move.RowIdx*b.Cols() + best.move.ColIdx + 1, nil
+}
+
+// outcome is how a game played out, the symbol is the player who completed a line and is empty when the game is drawn
+type outcome struct {
+	symbol board.BoxContent
+}
+
+// node is a position in the search tree, reached by a move of the player who moved into it
+type node struct {
+	move     board.Position
+	mover    board.BoxContent
+	turn     int // index in turn order of the player to move next
+	parent   *node
+	children []*node
+	untried  []board.Position // moves that have no child yet
+	ended    bool             // the move into the node has ended the game
+	result   outcome          // how the game ended when it has
+	visits   int
+	reward   float64 // sum of the rewards of the playouts through the node for the mover
+}
+
+// search holds the state shared by every iteration of a single tree search
+type search struct {
+	board     board.Board
+	turnOrder []board.BoxContent // symbols in the order they move, starting with the searching player
+	rng       *rand.Rand
+}
+
+// iterate runs a single iteration of the search, selecting a node with UCT, expanding it by a move not tried yet, playing out a random game from it
+// and adding the result to every node on the way back to the root, the board is left as it was
+func (s *search) iterate(root *node) {
+	moves := 0
+	defer func() {
+		for ; moves > 0; moves-- {
+			s.board.Undo()
+		}
+	}()
+
+	// selection, follows the best child until a node with untried moves or that has ended the game is reached
+	n := root
+	for !n.ended && len(n.untried) == 0 {
+		n = n.selectChild()
+		s.play(n.move, n.mover)
+		moves++
+	}
+
+	// expansion, adds a child for one of the untried moves
+	if !n.ended {
+		idx := s.rng.Intn(len(n.untried))
+		move := n.untried[idx]
+		n.untried[idx] = n.untried[len(n.untried)-1]
+		n.untried = n.untried[:len(n.untried)-1]
+
+		mover := s.turnOrder[n.turn]
+		s.play(move, mover)
+		moves++
+
+		child := s.newNode(n, move, mover, (n.turn+1)%len(s.turnOrder))
+		n.children = append(n.children, child)
+		n = child
+	}
+
+	// simulation, plays random moves until the game has ended
+	result := n.result
+	if !n.ended {
+		var playoutMoves int
+		result, playoutMoves = s.playout(n.turn)
+		moves += playoutMoves
+	}
+
+	// backpropagation
+	for ; n != nil; n = n.parent {
+		n.visits++
+		n.reward += s.reward(n.mover, result)
+	}
+}
+
+// newNode creates the node reached by the mover's move that has just been played on the board, working out if the move has ended the game
+func (s *search) newNode(parent *node, move board.Position, mover board.BoxContent, turn int) *node {
+	n := &node{
+		move:   move,
+		mover:  mover,
+		turn:   turn,
+		parent: parent,
+	}
+
+	checkForWinnerParams := board.CheckForWinnerParams{
+		PlayerSymbol: mover,
+		RowIdx:       move.RowIdx,
+		ColIdx:       move.ColIdx,
+	}
+
+	if parent != nil && s.board.CheckForWinner(checkForWinnerParams) {
+		n.ended = true
+		n.result = outcome{mover}
+		return n
+	}
+
+	n.untried = s.playableMoves()
+	if !s.board.Misere {
+		n.untried = s.decisiveMoves(n.untried, turn)
+	}
+
+	if len(n.untried) == 0 {
+		n.ended = true
+	}
+
+	return n
+}
+
+// decisiveMoves narrows the moves of the player at the turn index down to a move that wins on the spot, or otherwise to the moves that stop the next player from winning on their move
+// random playouts rarely find these moves among many boxes, so without this the search would spend most of its time on moves that lose straight away
+func (s *search) decisiveMoves(moves []board.Position, turn int) []board.Position {
+	symbol := s.turnOrder[turn]
+	next := s.turnOrder[(turn+1)%len(s.turnOrder)]
+
+	var blocks []board.Position
+	for _, move := range moves {
+		if s.completesLine(move, symbol) {
+			return []board.Position{move}
+		}
+		if s.completesLine(move, next) {
+			blocks = append(blocks, move)
+		}
+	}
+
+	if len(blocks) > 0 {
+		return blocks
+	}
+
+	return moves
+}
+
+// completesLine checks if filling the empty box at the position with the symbol would complete a line, the box is left empty
+func (s *search) completesLine(move board.Position, symbol board.BoxContent) bool {
+	s.board.Boxes[move.RowIdx][move.ColIdx] = symbol
+	defer func() {
+		s.board.Boxes[move.RowIdx][move.ColIdx] = board.E
+	}()
+
+	checkForWinnerParams := board.CheckForWinnerParams{
+		PlayerSymbol: symbol,
+		RowIdx:       move.RowIdx,
+		ColIdx:       move.ColIdx,
+	}
+
+	return s.board.CheckForWinner(checkForWinnerParams)
+}
+
+// selectChild returns the child with the highest upper confidence bound, balancing the reward of a move against how often it has been tried
+func (n *node) selectChild() *node {
+	logVisits := math.Log(float64(n.visits))
+
+	best := n.children[0]
+	bestScore := math.Inf(-1)
+	for _, child := range n.children {
+		score := child.reward/float64(child.visits) + exploration*math.Sqrt(logVisits/float64(child.visits))
+		if score > bestScore {
+			best = child
+			bestScore = score
+		}
+	}
+
+	return best
+}
+
+// playout plays random moves, starting with the player at the turn index, until a line is completed or no box is left and returns how the game ended
+// along with the number of moves played
+func (s *search) playout(turn int) (outcome, int) {
+	moves := 0
+
+	// without gravity every empty box stays playable, so the boxes are shuffled once and filled in that order
+	empty := s.playableMoves()
+	s.rng.Shuffle(len(empty), func(i, j int) {
+		empty[i], empty[j] = empty[j], empty[i]
+	})
+
+	for {
+		var move board.Position
+		if s.board.Gravity {
+			playable := s.playableMoves()
+			if len(playable) == 0 {
+				return outcome{}, moves
+			}
+			move = playable[s.rng.Intn(len(playable))]
+		} else {
+			if moves == len(empty) {
+				return outcome{}, moves
+			}
+			move = empty[moves]
+		}
+
+		symbol := s.turnOrder[turn]
+		s.play(move, symbol)
+		moves++
+
+		checkForWinnerParams := board.CheckForWinnerParams{
+			PlayerSymbol: symbol,
+			RowIdx:       move.RowIdx,
+			ColIdx:       move.ColIdx,
+		}
+
+		if s.board.CheckForWinner(checkForWinnerParams) {
+			return outcome{symbol}, moves
+		}
+
+		turn = (turn + 1) % len(s.turnOrder)
+	}
+}
+
+// reward returns how much a game that played out with the result is worth to the player with the symbol
+// a win is worth 1, a draw half and a loss nothing, under misere rules every player but the one who completed the line shares the win
+func (s *search) reward(symbol board.BoxContent, result outcome) float64 {
+	switch {
+	case result.symbol == board.E:
+		return 0.5
+	case (result.symbol == symbol) != s.board.Misere:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// play fills the box at the position with the symbol
+func (s *search) play(move board.Position, symbol board.BoxContent) {
+	insertBoxWithContentParams := board.InsertBoxWithContentParams{
+		RowIdx:  move.RowIdx,
+		ColIdx:  move.ColIdx,
+		Content: symbol,
+	}
+
+	s.board.SelectBox(insertBoxWithContentParams)
+}
+
+// playableMoves returns every box that can be filled by the next move
+func (s *search) playableMoves() []board.Position {
+	var moves []board.Position
+	for row := range s.board.Boxes {
+		for col := range s.board.Boxes[row] {
+			if s.board.IsPlayable(row, col) {
+				moves = append(moves, board.Position{RowIdx: row, ColIdx: col})
+			}
+		}
+	}
+
+	return moves
+}
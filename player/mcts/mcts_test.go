@@ -0,0 +1,198 @@
+package mcts
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dev-amos/tictactoe/board"
+)
+
+func TestChooseBox(t *testing.T) {
+	type args struct {
+		position   string
+		iterations int
+	}
+
+	type want struct {
+		err error
+		box int
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"completes its own row instead of blocking",
+			args{"3x3 3 xx1/oo1/3 x", 2000},
+			want{nil, 3},
+		},
+		{
+			"blocks the opponent's diagonal",
+			args{"3x3 3 o2/1o1/x2 x", 2000},
+			want{nil, 9},
+		},
+		{
+			"completes a line of 5 on a 15*15 board",
+			args{"15x15 5 15/15/15/15/15/5xxxx6/5oooo6/15/15/15/15/15/15/15/15 x", 5000},
+			want{nil, 5*15 + 5},
+		},
+		{
+			"blocks a line of 4 on a 15*15 board",
+			args{"15x15 5 15/15/15/15/15/4xoooo6/15/6x8/15/15/15/15/15/15/15 x", 5000},
+			want{nil, 5*15 + 10},
+		},
+		{
+			"returns the column to block four in a row with gravity",
+			args{"5x5 4 5/5/5/x4/xooo1 x g", 2000},
+			want{nil, 5},
+		},
+		{
+			"returns error when the board is full",
+			args{"3x3 3 xox/xoo/oxx -", 100},
+			want{ErrNoBoxAvailable, 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, _, err := board.ParsePosition(test.args.position)
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			newPlayerParams := NewPlayerParams{
+				Name:       "computer",
+				Symbol:     board.X,
+				Opponents:  []board.BoxContent{board.O},
+				Iterations: test.args.iterations,
+				Seed:       1,
+			}
+			p := NewPlayer(newPlayerParams)
+
+			before := b.FormatPosition(board.E)
+			gotBox, err := p.ChooseBox(context.Background(), b)
+
+			if !reflect.DeepEqual(err, test.want.err) {
+				t.Errorf("unexpected error = %v, want %v", err, test.want.err)
+			}
+
+			if gotBox != test.want.box {
+				t.Errorf("unexpected box = %d, want %d", gotBox, test.want.box)
+			}
+
+			if after := b.FormatPosition(board.E); after != before || len(b.History()) != 0 {
+				t.Errorf("unexpected board after choosing = %s, want %s", after, before)
+			}
+
+		})
+	}
+
+}
+
+func TestChooseBoxWithSeed(t *testing.T) {
+	b, _, err := board.ParsePosition("7x7 4 7/7/7/3x3/2o4/7/7 x")
+	if err != nil {
+		t.Fatalf("unexpected error = %v, want %v", err, nil)
+	}
+
+	var boxes []int
+	for i := 0; i < 2; i++ {
+		newPlayerParams := NewPlayerParams{
+			Name:       "computer",
+			Symbol:     board.X,
+			Opponents:  []board.BoxContent{board.O},
+			Iterations: 500,
+			Seed:       42,
+		}
+		p := NewPlayer(newPlayerParams)
+
+		// the same player is asked twice so that the random numbers carried over from the first move are also the same
+		for j := 0; j < 2; j++ {
+			box, err := p.ChooseBox(context.Background(), b)
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+			boxes = append(boxes, box)
+		}
+	}
+
+	if !reflect.DeepEqual(boxes[:2], boxes[2:]) {
+		t.Errorf("unexpected boxes = %v, want %v", boxes[2:], boxes[:2])
+	}
+
+}
+
+func TestChooseBoxWithDeadline(t *testing.T) {
+	type args struct {
+		position string
+		timeout  time.Duration
+	}
+
+	type want struct {
+		boxes  []int // any of the boxes is right
+		within time.Duration
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			"returns the only box tried when the deadline has already passed",
+			args{"4x4 3 4/1x2/2o1/4 x", 0},
+			want{[]int{16}, time.Second},
+		},
+		{
+			"finds a box that wins in 3 moves when given time to run playouts until the deadline",
+			args{"4x4 3 4/1x2/2o1/4 x", 200 * time.Millisecond},
+			want{[]int{7, 10}, time.Second},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, _, err := board.ParsePosition(test.args.position)
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			// without an iteration budget playouts are run until the deadline, at least one is run so that there is a box to return
+			newPlayerParams := NewPlayerParams{
+				Name:      "computer",
+				Symbol:    board.X,
+				Opponents: []board.BoxContent{board.O},
+				Seed:      1,
+			}
+			p := NewPlayer(newPlayerParams)
+
+			ctx, cancel := context.WithTimeout(context.Background(), test.args.timeout)
+			defer cancel()
+
+			start := time.Now()
+			gotBox, err := p.ChooseBox(ctx, b)
+
+			if err != nil {
+				t.Errorf("unexpected error = %v, want %v", err, nil)
+			}
+
+			found := false
+			for _, box := range test.want.boxes {
+				found = found || gotBox == box
+			}
+			if !found {
+				t.Errorf("unexpected box = %d, want one of %v", gotBox, test.want.boxes)
+			}
+
+			if elapsed := time.Since(start); elapsed > test.want.within {
+				t.Errorf("unexpected time taken = %v, want less than %v", elapsed, test.want.within)
+			}
+
+		})
+	}
+
+}
@@ -2,11 +2,54 @@
 package player
 
 import (
+	"context"
+	"errors"
+
 	"github.com/dev-amos/tictactoe/board"
 )
 
+var (
+	ErrUnknownLevel = errors.New("level must be one of easy, medium, hard or perfect")
+)
+
+// Level is how strongly a computer player plays
+type Level string
+
+// Levels a computer player can play at
+const (
+	Easy    Level = "easy"    // Looks at its own move only and often plays a random box instead
+	Medium  Level = "medium"  // Looks at the reply to its move and sometimes plays a random box instead
+	Hard    Level = "hard"    // Searches as far as it can in the time a move should take
+	Perfect Level = "perfect" // Never loses a game that can be saved, on boards too big to solve it plays as hard
+)
+
+// Levels are the levels a computer player can play at, from the weakest to the strongest
+var Levels = []Level{Easy, Medium, Hard, Perfect}
+
+// ParseLevel returns the level with the name given
+func ParseLevel(name string) (Level, error) {
+	for _, level := range Levels {
+		if string(level) == name {
+			return level, nil
+		}
+	}
+
+	return "", ErrUnknownLevel
+}
+
 // Player of tic-tac-toe.
 type Player interface {
 	GetName() string
 	GetSymbol() board.BoxContent
 }
+
+// Computer is a player of tic-tac-toe that chooses its own moves instead of being prompted by the view.
+type Computer interface {
+	Player
+	// ChooseBox returns the numbered position of the box the player wants to fill, using the same numbering shown by the view
+	// on a board with gravity it returns the numbered column to drop the symbol into instead
+	// a player that searches for its move returns the best move it has found once the context is done, such as when its deadline has passed
+	ChooseBox(ctx context.Context, b *board.Board) (int, error)
+	// GetLevel returns the level the player was created to play at, it is empty when the player was not created for a level
+	GetLevel() Level
+}
@@ -0,0 +1,203 @@
+// Package record saves tic tac toe games as JSON so that an interrupted game can be loaded and resumed later
+package record
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/dev-amos/tictactoe/board"
+	"github.com/dev-amos/tictactoe/game"
+	"github.com/dev-amos/tictactoe/player"
+)
+
+var (
+	ErrBoardMissing    = errors.New("record has no board")
+	ErrInvalidPlayer   = errors.New("record has a player with an unknown type or symbol")
+	ErrDuplicateSymbol = errors.New("record has players sharing the same symbol")
+	ErrPlayersMismatch = errors.New("players given do not match the players of the record")
+	ErrMoveOutOfTurn   = errors.New("record has a move made out of turn")
+	ErrMoveAfterEnd    = errors.New("record has a move made after the game was over")
+)
+
+// Types of players kept in a record
+const (
+	HumanPlayer    = "human"
+	ComputerPlayer = "computer"
+)
+
+// Record is a game saved with its board, including the moves made on it, and its players in turn order
+type Record struct {
+	Board   *board.Board `json:"board"`
+	Players []Player     `json:"players"`
+}
+
+// Player is a player of a saved game
+type Player struct {
+	Name   string           `json:"name"`
+	Symbol board.BoxContent `json:"symbol"`
+	Type   string           `json:"type"`
+	Level  player.Level     `json:"level,omitempty"` // level a computer plays at, empty for a human
+}
+
+// NewRecord creates the record of a game
+func NewRecord(g *game.Game) Record {
+	players := make([]Player, 0, len(g.Players()))
+	for _, p := range g.Players() {
+		playerType := HumanPlayer
+		var level player.Level
+		if computer, ok := p.(player.Computer); ok {
+			playerType = ComputerPlayer
+			level = computer.GetLevel()
+		}
+
+		players = append(players, Player{
+			Name:   p.GetName(),
+			Symbol: p.GetSymbol(),
+			Type:   playerType,
+			Level:  level,
+		})
+	}
+
+	return Record{
+		Board:   g.Board(),
+		Players: players,
+	}
+}
+
+// Write writes the record of a game as JSON
+func Write(w io.Writer, g *game.Game) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(NewRecord(g))
+}
+
+// Read reads a record written as JSON and checks that its board and players are valid
+func Read(r io.Reader) (Record, error) {
+	var rec Record
+	if err := json.NewDecoder(r).Decode(&rec); err != nil {
+		return Record{}, err
+	}
+
+	if rec.Board == nil {
+		return Record{}, ErrBoardMissing
+	} else if len(rec.Players) < 2 {
+		return Record{}, game.ErrNotEnoughPlayers
+	}
+
+	symbols := make(map[board.BoxContent]bool, len(rec.Players))
+	for i, p := range rec.Players {
+		if p.Type != HumanPlayer && p.Type != ComputerPlayer || p.Symbol == board.E {
+			return Record{}, ErrInvalidPlayer
+		} else if symbols[p.Symbol] {
+			return Record{}, ErrDuplicateSymbol
+		}
+		symbols[p.Symbol] = true
+
+		if p.Type == HumanPlayer && p.Level != "" {
+			return Record{}, ErrInvalidPlayer
+		} else if p.Type == HumanPlayer {
+			continue
+		}
+
+		// computers saved before levels were kept play at the level they used to play at
+		if p.Level == "" {
+			rec.Players[i].Level = player.Hard
+		} else if _, err := player.ParseLevel(string(p.Level)); err != nil {
+			return Record{}, ErrInvalidPlayer
+		}
+	}
+
+	return rec, nil
+}
+
+// NewGameParams defines the structure for the parameters needed to resume the game of a record
+type NewGameParams struct {
+	// Players play the saved players, in the same turn order and with the same symbols
+	Players []player.Player
+}
+
+// NewGame replays the moves of the record on the board the game started from and returns the game as it was when it was saved
+// every move is checked to have been made by the player whose turn it was, the first move is made by the first player,
+// and a record with moves left once the game is over is refused so that a corrupt record is never resumed as a shorter game
+func (rec Record) NewGame(p NewGameParams) (*game.Game, error) {
+	if len(p.Players) != len(rec.Players) {
+		return nil, ErrPlayersMismatch
+	}
+
+	for i := range p.Players {
+		if p.Players[i].GetSymbol() != rec.Players[i].Symbol {
+			return nil, ErrPlayersMismatch
+		}
+	}
+
+	newBoardParams := board.NewBoardParams{
+		WinCount: rec.Board.WinCount,
+		Rows:     rec.Board.Rows(),
+		Cols:     rec.Board.Cols(),
+		Gravity:  rec.Board.Gravity,
+		Misere:   rec.Board.Misere,
+	}
+
+	b, err := board.NewBoard(newBoardParams)
+	if err != nil {
+		return nil, err
+	}
+
+	// boxes that were not filled by any of the moves were already filled when the game started, such as a game started from a position string
+	history := rec.Board.History()
+	for row := range b.Boxes {
+		copy(b.Boxes[row], rec.Board.Boxes[row])
+	}
+	for _, move := range history {
+		b.Boxes[move.RowIdx][move.ColIdx] = board.E
+	}
+	b.RecomputeHash()
+
+	newGameParams := game.NewGameParams{
+		Board:   b,
+		Players: p.Players,
+	}
+
+	g, err := game.NewGame(newGameParams)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, move := range history {
+		if g.Status() != game.InProgress {
+			return nil, ErrMoveAfterEnd
+		}
+
+		if err := PlayMove(g, move); err != nil {
+			return nil, err
+		}
+	}
+
+	return g, nil
+}
+
+// PlayMove plays a recorded move in the game, the move has to be made with the current player's symbol
+func PlayMove(g *game.Game, move board.Move) error {
+	if g.Status() == game.InProgress && move.Content != g.CurrentPlayer().GetSymbol() {
+		return ErrMoveOutOfTurn
+	}
+
+	// boxes are numbered from 1, while a board with gravity only needs the column
+	position := move.RowIdx*g.Board().Cols() + move.ColIdx + 1
+	if g.Board().Gravity {
+		position = move.ColIdx + 1
+	}
+
+	if err := g.Play(position); err != nil {
+		return err
+	}
+
+	// with gravity the piece falls to the lowest empty box, which has to be where the move was recorded
+	if history := g.Board().History(); history[len(history)-1] != move {
+		return board.ErrBoxNotSupported
+	}
+
+	return nil
+}
@@ -0,0 +1,320 @@
+package record
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dev-amos/tictactoe/board"
+	"github.com/dev-amos/tictactoe/game"
+	"github.com/dev-amos/tictactoe/player"
+	"github.com/dev-amos/tictactoe/player/ai"
+	"github.com/dev-amos/tictactoe/player/real"
+)
+
+var testPlayers = []player.Player{
+	real.NewPlayer(real.NewPlayerParams{Name: "first", Symbol: board.X}),
+	ai.NewPlayer(ai.NewPlayerParams{Name: "second", Symbol: board.O, Opponents: []board.BoxContent{board.X}, Level: player.Medium}),
+}
+
+func TestWriteAndRead(t *testing.T) {
+	type want struct {
+		status        game.Status
+		currentPlayer string
+		boxes         [][]board.BoxContent
+	}
+
+	tests := []struct {
+		name    string
+		gravity bool
+		moves   []int
+		want    want
+	}{
+		{
+			"resumes a game in progress",
+			false,
+			[]int{5, 1, 9},
+			want{
+				game.InProgress,
+				"second",
+				[][]board.BoxContent{
+					{board.O, board.E, board.E},
+					{board.E, board.X, board.E},
+					{board.E, board.E, board.X},
+				},
+			},
+		},
+		{
+			"resumes a game that has been won",
+			false,
+			[]int{1, 4, 2, 5, 3},
+			want{
+				game.Won,
+				"first",
+				[][]board.BoxContent{
+					{board.X, board.X, board.X},
+					{board.O, board.O, board.E},
+					{board.E, board.E, board.E},
+				},
+			},
+		},
+		{
+			"resumes a game with gravity",
+			true,
+			[]int{2, 2, 3},
+			want{
+				game.InProgress,
+				"second",
+				[][]board.BoxContent{
+					{board.E, board.E, board.E},
+					{board.E, board.O, board.E},
+					{board.E, board.X, board.X},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, _ := board.NewBoard(board.NewBoardParams{WinCount: 3, Rows: 3, Cols: 3, Gravity: test.gravity})
+			g, _ := game.NewGame(game.NewGameParams{Board: b, Players: testPlayers})
+			for _, move := range test.moves {
+				if err := g.Play(move); err != nil {
+					t.Fatalf("unexpected error = %v, want %v", err, nil)
+				}
+			}
+
+			var buf bytes.Buffer
+			if err := Write(&buf, g); err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			rec, err := Read(&buf)
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			wantPlayers := []Player{{"first", board.X, HumanPlayer, ""}, {"second", board.O, ComputerPlayer, player.Medium}}
+			if !reflect.DeepEqual(rec.Players, wantPlayers) {
+				t.Errorf("unexpected players = %v, want %v", rec.Players, wantPlayers)
+			}
+
+			resumed, err := rec.NewGame(NewGameParams{Players: testPlayers})
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			if resumed.Status() != test.want.status {
+				t.Errorf("unexpected status = %v, want %v", resumed.Status(), test.want.status)
+			}
+
+			if resumed.CurrentPlayer().GetName() != test.want.currentPlayer {
+				t.Errorf("unexpected current player = %s, want %s", resumed.CurrentPlayer().GetName(), test.want.currentPlayer)
+			}
+
+			if !reflect.DeepEqual(resumed.Board().Boxes, test.want.boxes) {
+				t.Errorf("unexpected Boxes = %v, want %v", resumed.Board().Boxes, test.want.boxes)
+			}
+
+			if !reflect.DeepEqual(resumed.Board().History(), b.History()) {
+				t.Errorf("unexpected history = %v, want %v", resumed.Board().History(), b.History())
+			}
+
+		})
+	}
+
+}
+
+func TestReadInvalidRecord(t *testing.T) {
+	const players = `"players":[{"name":"first","symbol":"x","type":"human"},{"name":"second","symbol":"o","type":"computer"}]`
+
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{
+			"returns error when the board is missing",
+			`{` + players + `}`,
+			ErrBoardMissing,
+		},
+		{
+			"returns error when the board is corrupt",
+			`{"board":{"rows":2,"cols":2,"winCount":2,"boxes":[["x"]]},` + players + `}`,
+			board.ErrInvalidBoxes,
+		},
+		{
+			"returns error when there is a single player",
+			`{"board":{"rows":2,"cols":2,"winCount":2,"boxes":[["",""],["",""]]},"players":[{"name":"first","symbol":"x","type":"human"}]}`,
+			game.ErrNotEnoughPlayers,
+		},
+		{
+			"returns error when a player has an unknown type",
+			`{"board":{"rows":2,"cols":2,"winCount":2,"boxes":[["",""],["",""]]},"players":[{"name":"first","symbol":"x","type":"robot"},{"name":"second","symbol":"o","type":"human"}]}`,
+			ErrInvalidPlayer,
+		},
+		{
+			"returns error when a computer has an unknown level",
+			`{"board":{"rows":2,"cols":2,"winCount":2,"boxes":[["",""],["",""]]},"players":[{"name":"first","symbol":"x","type":"human"},{"name":"second","symbol":"o","type":"computer","level":"impossible"}]}`,
+			ErrInvalidPlayer,
+		},
+		{
+			"returns error when a human has a level",
+			`{"board":{"rows":2,"cols":2,"winCount":2,"boxes":[["",""],["",""]]},"players":[{"name":"first","symbol":"x","type":"human","level":"easy"},{"name":"second","symbol":"o","type":"computer"}]}`,
+			ErrInvalidPlayer,
+		},
+		{
+			"returns error when players share a symbol",
+			`{"board":{"rows":2,"cols":2,"winCount":2,"boxes":[["",""],["",""]]},"players":[{"name":"first","symbol":"x","type":"human"},{"name":"second","symbol":"x","type":"human"}]}`,
+			ErrDuplicateSymbol,
+		},
+		{
+			"returns error when the record is not JSON",
+			`board`,
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(test.data))
+
+			if err == nil {
+				t.Fatalf("unexpected error = %v, want an error", err)
+			}
+
+			if test.want != nil && !errors.Is(err, test.want) {
+				t.Errorf("unexpected error = %v, want %v", err, test.want)
+			}
+
+		})
+	}
+
+}
+
+func TestReadComputerWithoutLevel(t *testing.T) {
+	const data = `{"board":{"rows":2,"cols":2,"winCount":2,"boxes":[["",""],["",""]]},"players":[{"name":"first","symbol":"x","type":"human"},{"name":"second","symbol":"o","type":"computer"}]}`
+
+	rec, err := Read(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error = %v, want %v", err, nil)
+	}
+
+	wantPlayers := []Player{{"first", board.X, HumanPlayer, ""}, {"second", board.O, ComputerPlayer, player.Hard}}
+	if !reflect.DeepEqual(rec.Players, wantPlayers) {
+		t.Errorf("unexpected players = %v, want %v", rec.Players, wantPlayers)
+	}
+
+}
+
+func TestNewGame(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		players []player.Player
+		want    error
+	}{
+		{
+			"returns error when a move is made out of turn",
+			`{"board":{"rows":3,"cols":3,"winCount":3,"boxes":[["o","",""],["","",""],["","",""]],"moves":[{"row":0,"col":0,"symbol":"o"}]}}`,
+			testPlayers,
+			ErrMoveOutOfTurn,
+		},
+		{
+			"resumes a game started with a box that is not filled by any move",
+			`{"board":{"rows":3,"cols":3,"winCount":3,"boxes":[["x","",""],["","","o"],["","",""]],"moves":[{"row":0,"col":0,"symbol":"x"}]}}`,
+			testPlayers,
+			nil,
+		},
+		{
+			"returns error when a piece is recorded above an empty box with gravity",
+			`{"board":{"rows":3,"cols":3,"winCount":3,"gravity":true,"boxes":[["x","",""],["","",""],["","",""]],"moves":[{"row":0,"col":0,"symbol":"x"}]}}`,
+			testPlayers,
+			board.ErrBoxNotSupported,
+		},
+		{
+			"returns error when a move is recorded after the game was won",
+			`{"board":{"rows":3,"cols":3,"winCount":3,"boxes":[["x","x","x"],["o","o",""],["","","o"]],"moves":[{"row":0,"col":0,"symbol":"x"},{"row":1,"col":0,"symbol":"o"},{"row":0,"col":1,"symbol":"x"},{"row":1,"col":1,"symbol":"o"},{"row":0,"col":2,"symbol":"x"},{"row":2,"col":2,"symbol":"o"}]}}`,
+			testPlayers,
+			ErrMoveAfterEnd,
+		},
+		{
+			"returns error when the players do not match the record",
+			`{"board":{"rows":3,"cols":3,"winCount":3,"boxes":[["","",""],["","",""],["","",""]]}}`,
+			[]player.Player{testPlayers[1], testPlayers[0]},
+			ErrPlayersMismatch,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := strings.TrimSuffix(test.data, "}") + `,"players":[{"name":"first","symbol":"x","type":"human"},{"name":"second","symbol":"o","type":"computer"}]}`
+
+			rec, err := Read(strings.NewReader(data))
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			_, err = rec.NewGame(NewGameParams{Players: test.players})
+
+			if !errors.Is(err, test.want) {
+				t.Errorf("unexpected error = %v, want %v", err, test.want)
+			}
+
+		})
+	}
+
+}
+
+func TestNewGameHash(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		moves []board.InsertBoxWithContentParams
+	}{
+		{
+			"hashes a resumed game the same as the moves played on an empty board",
+			`{"board":{"rows":3,"cols":3,"winCount":3,"boxes":[["x","o",""],["","x",""],["","",""]],"moves":[{"row":0,"col":0,"symbol":"x"},{"row":0,"col":1,"symbol":"o"},{"row":1,"col":1,"symbol":"x"}]}}`,
+			[]board.InsertBoxWithContentParams{{RowIdx: 0, ColIdx: 0, Content: board.X}, {RowIdx: 0, ColIdx: 1, Content: board.O}, {RowIdx: 1, ColIdx: 1, Content: board.X}},
+		},
+		{
+			"hashes a game started with a box that is not filled by any move the same as the boxes filled on an empty board",
+			`{"board":{"rows":3,"cols":3,"winCount":3,"boxes":[["x","",""],["","","o"],["","",""]],"moves":[{"row":0,"col":0,"symbol":"x"}]}}`,
+			[]board.InsertBoxWithContentParams{{RowIdx: 1, ColIdx: 2, Content: board.O}, {RowIdx: 0, ColIdx: 0, Content: board.X}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data := strings.TrimSuffix(test.data, "}") + `,"players":[{"name":"first","symbol":"x","type":"human"},{"name":"second","symbol":"o","type":"computer"}]}`
+
+			rec, err := Read(strings.NewReader(data))
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			g, err := rec.NewGame(NewGameParams{Players: testPlayers})
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			expectBoard, err := board.NewBoard(board.NewBoardParams{WinCount: 3, Rows: 3, Cols: 3})
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+			for _, move := range test.moves {
+				if err := expectBoard.SelectBox(move); err != nil {
+					t.Fatalf("unexpected error = %v, want %v", err, nil)
+				}
+			}
+
+			if got, want := g.Board().Hash(), expectBoard.Hash(); got != want {
+				t.Errorf("unexpected hash = %d, want %d", got, want)
+			}
+
+		})
+	}
+
+}
@@ -0,0 +1,128 @@
+package record
+
+import (
+	"errors"
+
+	"github.com/dev-amos/tictactoe/board"
+	"github.com/dev-amos/tictactoe/game"
+	"github.com/dev-amos/tictactoe/player"
+	"github.com/dev-amos/tictactoe/player/real"
+)
+
+var (
+	ErrReplayAtStart = errors.New("replay is at the start of the game")
+	ErrReplayAtEnd   = errors.New("replay is at the last move of the game")
+)
+
+// Replay steps forwards and backwards through the moves of a record, the game is kept at the position after the moves stepped through
+type Replay struct {
+	game  *game.Game
+	moves []board.Move
+	step  int
+}
+
+// NewReplay creates a replay of the record positioned before its first move
+func (rec Record) NewReplay() (*Replay, error) {
+	players := make([]player.Player, 0, len(rec.Players))
+	for _, p := range rec.Players {
+		newPlayerParams := real.NewPlayerParams{
+			Name:   p.Name,
+			Symbol: p.Symbol,
+		}
+		players = append(players, real.NewPlayer(newPlayerParams))
+	}
+
+	g, err := rec.NewGame(NewGameParams{Players: players})
+	if err != nil {
+		return nil, err
+	}
+
+	// the moves taken back are kept by the board, so stepping forwards redoes them in the order they were made
+	moves := g.Board().History()
+	for range moves {
+		if err := g.Undo(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Replay{
+		game:  g,
+		moves: moves,
+	}, nil
+}
+
+// Game returns the game at the position the replay has stepped to
+func (r *Replay) Game() *game.Game {
+	return r.game
+}
+
+// Step returns the number of moves that have been stepped through
+func (r *Replay) Step() int {
+	return r.step
+}
+
+// Len returns the number of moves of the record
+func (r *Replay) Len() int {
+	return len(r.moves)
+}
+
+// LastMove returns the move that has just been stepped through and the player who made it, the player is nil at the start of the game
+func (r *Replay) LastMove() (board.Move, player.Player) {
+	if r.step == 0 {
+		return board.Move{}, nil
+	}
+
+	move := r.moves[r.step-1]
+	for _, p := range r.game.Players() {
+		if p.GetSymbol() == move.Content {
+			return move, p
+		}
+	}
+
+	return move, nil
+}
+
+// Next plays the next move of the record
+func (r *Replay) Next() error {
+	if r.step == len(r.moves) {
+		return ErrReplayAtEnd
+	}
+
+	if err := r.game.Redo(); err != nil {
+		return err
+	}
+	r.step++
+
+	return nil
+}
+
+// Previous takes back the last move stepped through
+func (r *Replay) Previous() error {
+	if r.step == 0 {
+		return ErrReplayAtStart
+	}
+
+	if err := r.game.Undo(); err != nil {
+		return err
+	}
+	r.step--
+
+	return nil
+}
+
+// Seek steps forwards or backwards until the number of moves stepped through is the step given, it is kept within the moves of the record
+func (r *Replay) Seek(step int) error {
+	for r.step < step && r.step < len(r.moves) {
+		if err := r.Next(); err != nil {
+			return err
+		}
+	}
+
+	for r.step > step && r.step > 0 {
+		if err := r.Previous(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package record
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dev-amos/tictactoe/board"
+	"github.com/dev-amos/tictactoe/game"
+)
+
+// wonRecord is a game won by first on the top row with its fifth move
+const wonRecord = `{
+	"board": {
+		"rows": 3, "cols": 3, "winCount": 3,
+		"boxes": [["x", "x", "x"], ["o", "o", ""], ["", "", ""]],
+		"moves": [
+			{"row": 0, "col": 0, "symbol": "x"},
+			{"row": 1, "col": 0, "symbol": "o"},
+			{"row": 0, "col": 1, "symbol": "x"},
+			{"row": 1, "col": 1, "symbol": "o"},
+			{"row": 0, "col": 2, "symbol": "x"}
+		]
+	},
+	"players": [{"name": "first", "symbol": "x", "type": "human"}, {"name": "second", "symbol": "o", "type": "computer"}]
+}`
+
+func TestReplay(t *testing.T) {
+	type want struct {
+		err      error
+		step     int
+		status   game.Status
+		lastMove board.Move
+		player   string
+	}
+
+	tests := []struct {
+		name  string
+		steps func(r *Replay) error
+		want  want
+	}{
+		{
+			"starts before the first move",
+			func(r *Replay) error {
+				return nil
+			},
+			want{
+				nil,
+				0,
+				game.InProgress,
+				board.Move{},
+				"",
+			},
+		},
+		{
+			"steps forwards to the winning move",
+			func(r *Replay) error {
+				for i := 0; i < 5; i++ {
+					if err := r.Next(); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			want{
+				nil,
+				5,
+				game.Won,
+				board.Move{RowIdx: 0, ColIdx: 2, Content: board.X},
+				"first",
+			},
+		},
+		{
+			"steps back from the winning move",
+			func(r *Replay) error {
+				if err := r.Seek(5); err != nil {
+					return err
+				}
+				return r.Previous()
+			},
+			want{
+				nil,
+				4,
+				game.InProgress,
+				board.Move{RowIdx: 1, ColIdx: 1, Content: board.O},
+				"second",
+			},
+		},
+		{
+			"seeks back and forth within the moves",
+			func(r *Replay) error {
+				if err := r.Seek(10); err != nil {
+					return err
+				}
+				return r.Seek(2)
+			},
+			want{
+				nil,
+				2,
+				game.InProgress,
+				board.Move{RowIdx: 1, ColIdx: 0, Content: board.O},
+				"second",
+			},
+		},
+		{
+			"returns error when stepping past the last move",
+			func(r *Replay) error {
+				r.Seek(5)
+				return r.Next()
+			},
+			want{
+				ErrReplayAtEnd,
+				5,
+				game.Won,
+				board.Move{RowIdx: 0, ColIdx: 2, Content: board.X},
+				"first",
+			},
+		},
+		{
+			"returns error when stepping back before the first move",
+			func(r *Replay) error {
+				return r.Previous()
+			},
+			want{
+				ErrReplayAtStart,
+				0,
+				game.InProgress,
+				board.Move{},
+				"",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec, err := Read(strings.NewReader(wonRecord))
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			r, err := rec.NewReplay()
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			if err := test.steps(r); !errors.Is(err, test.want.err) {
+				t.Errorf("unexpected error = %v, want %v", err, test.want.err)
+			}
+
+			if r.Step() != test.want.step {
+				t.Errorf("unexpected step = %d, want %d", r.Step(), test.want.step)
+			}
+
+			if r.Game().Status() != test.want.status {
+				t.Errorf("unexpected status = %v, want %v", r.Game().Status(), test.want.status)
+			}
+
+			lastMove, p := r.LastMove()
+			if lastMove != test.want.lastMove {
+				t.Errorf("unexpected last move = %v, want %v", lastMove, test.want.lastMove)
+			}
+
+			var playerName string
+			if p != nil {
+				playerName = p.GetName()
+			}
+			if playerName != test.want.player {
+				t.Errorf("unexpected player = %s, want %s", playerName, test.want.player)
+			}
+
+			if filled := len(r.Game().Board().History()); filled != test.want.step {
+				t.Errorf("unexpected moves on board = %d, want %d", filled, test.want.step)
+			}
+
+		})
+	}
+
+}
@@ -0,0 +1,381 @@
+// Package server exposes tic tac toe games over a HTTP JSON API so that many games can be hosted and played at the same time
+//
+//	POST /games             creates a game, the body is a createGameRequest
+//	GET  /games             lists the games that are still in progress
+//	GET  /games/{id}        returns the state of a game
+//	POST /games/{id}/moves  plays a move for the current player, the body is a moveRequest
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dev-amos/tictactoe/board"
+	"github.com/dev-amos/tictactoe/game"
+	"github.com/dev-amos/tictactoe/player"
+	"github.com/dev-amos/tictactoe/player/real"
+)
+
+var (
+	ErrGameNotFound     = errors.New("game does not exist")
+	ErrTooManyPlayers   = fmt.Errorf("game can have at most %d players", len(board.PlayerSymbols))
+	ErrMethodNotAllowed = errors.New("method is not allowed on this path")
+	ErrBoardTooLarge    = fmt.Errorf("board can have at most %d boxes", maxBoxes)
+	ErrTooManyGames     = fmt.Errorf("server can host at most %d games", maxGames)
+)
+
+// maxBoxes is the number of boxes a board created through the API can have at most, so that a single request cannot use up the memory of the server
+const maxBoxes = 10000
+
+// maxGames is the number of games the server hosts at most, finished games are removed to make room for new ones once it is reached
+const maxGames = 1000
+
+// maxBodyBytes is the size a request body can have at most, every request body of the API is far smaller
+const maxBodyBytes = 1 << 16
+
+// Server holds every game created through the API, it is safe for use by concurrent requests
+type Server struct {
+	mu     sync.RWMutex
+	games  map[string]*hostedGame
+	nextID int
+}
+
+// hostedGame is a game held by the server, its lock is taken for every read or move because the board is not safe for concurrent use
+type hostedGame struct {
+	mu   sync.Mutex
+	id   string
+	game *game.Game
+}
+
+// createGameRequest is the body of a request to create a game
+// Dimension creates a square board and is only used when Rows and Cols are not given
+type createGameRequest struct {
+	Dimension int      `json:"dimension"`
+	Rows      int      `json:"rows"`
+	Cols      int      `json:"cols"`
+	WinCount  int      `json:"winCount"`
+	Gravity   bool     `json:"gravity"`
+	Misere    bool     `json:"misere"`
+	Players   []string `json:"players"`
+}
+
+// moveRequest is the body of a request to play a move, Position is the numbered box or the numbered column with gravity
+type moveRequest struct {
+	Position int `json:"position"`
+}
+
+// playerResponse is a player of a game
+type playerResponse struct {
+	Name   string `json:"name"`
+	Symbol string `json:"symbol"`
+}
+
+// positionResponse is the row and col index of a box
+type positionResponse struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+// gameResponse is the state of a game
+type gameResponse struct {
+	ID            string             `json:"id"`
+	Rows          int                `json:"rows"`
+	Cols          int                `json:"cols"`
+	WinCount      int                `json:"winCount"`
+	Gravity       bool               `json:"gravity"`
+	Misere        bool               `json:"misere"`
+	Boxes         [][]string         `json:"boxes"`
+	Players       []playerResponse   `json:"players"`
+	CurrentPlayer string             `json:"currentPlayer"`
+	Status        string             `json:"status"`
+	Winner        string             `json:"winner,omitempty"`
+	Loser         string             `json:"loser,omitempty"`
+	WinningLine   []positionResponse `json:"winningLine,omitempty"`
+	Moves         int                `json:"moves"`
+}
+
+// errorResponse is the body returned when a request fails
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewServer creates a server without any games
+func NewServer() *Server {
+	return &Server{
+		games:  make(map[string]*hostedGame),
+		nextID: 1,
+	}
+}
+
+// ServeHTTP routes a request to the handler for its path and method
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case len(parts) == 1 && parts[0] == "games":
+		switch r.Method {
+		case http.MethodPost:
+			s.createGame(w, r)
+		case http.MethodGet:
+			s.listGames(w)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+		}
+
+	case len(parts) == 2 && parts[0] == "games":
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+		s.getGame(w, parts[1])
+
+	case len(parts) == 3 && parts[0] == "games" && parts[2] == "moves":
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+			return
+		}
+		s.playMove(w, r, parts[1])
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// createGame creates a board and a game from the request body and starts hosting it
+func (s *Server) createGame(w http.ResponseWriter, r *http.Request) {
+	var req createGameRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if req.Rows == 0 && req.Cols == 0 {
+		req.Rows, req.Cols = req.Dimension, req.Dimension
+	}
+
+	// each side is checked on its own first so that multiplying them cannot overflow
+	if req.Rows > maxBoxes || req.Cols > maxBoxes || req.Rows*req.Cols > maxBoxes {
+		writeError(w, http.StatusBadRequest, ErrBoardTooLarge)
+		return
+	}
+
+	if len(req.Players) == 0 {
+		req.Players = []string{"Player 1", "Player 2"}
+	} else if len(req.Players) > len(board.PlayerSymbols) {
+		writeError(w, http.StatusBadRequest, ErrTooManyPlayers)
+		return
+	}
+
+	newBoardParams := board.NewBoardParams{
+		WinCount: req.WinCount,
+		Rows:     req.Rows,
+		Cols:     req.Cols,
+		Gravity:  req.Gravity,
+		Misere:   req.Misere,
+	}
+
+	b, err := board.NewBoard(newBoardParams)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	players := make([]player.Player, 0, len(req.Players))
+	for i, name := range req.Players {
+		newPlayerParams := real.NewPlayerParams{
+			Name:   name,
+			Symbol: board.PlayerSymbols[i],
+		}
+		players = append(players, real.NewPlayer(newPlayerParams))
+	}
+
+	newGameParams := game.NewGameParams{
+		Board:   b,
+		Players: players,
+	}
+
+	g, err := game.NewGame(newGameParams)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.mu.Lock()
+	if len(s.games) >= maxGames {
+		s.removeFinishedGames()
+	}
+	if len(s.games) >= maxGames {
+		s.mu.Unlock()
+		writeError(w, http.StatusServiceUnavailable, ErrTooManyGames)
+		return
+	}
+
+	hg := &hostedGame{
+		id:   strconv.Itoa(s.nextID),
+		game: g,
+	}
+	s.games[hg.id] = hg
+	s.nextID++
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, hg.snapshot())
+}
+
+// listGames returns the state of every game that is still in progress, ordered by when they were created
+func (s *Server) listGames(w http.ResponseWriter) {
+	s.mu.RLock()
+	hostedGames := make([]*hostedGame, 0, len(s.games))
+	for _, hg := range s.games {
+		hostedGames = append(hostedGames, hg)
+	}
+	s.mu.RUnlock()
+
+	responses := make([]gameResponse, 0, len(hostedGames))
+	for _, hg := range hostedGames {
+		if res := hg.snapshot(); res.Status == game.InProgress.String() {
+			responses = append(responses, res)
+		}
+	}
+
+	sort.Slice(responses, func(i, j int) bool {
+		idI, _ := strconv.Atoi(responses[i].ID)
+		idJ, _ := strconv.Atoi(responses[j].ID)
+		return idI < idJ
+	})
+
+	writeJSON(w, http.StatusOK, responses)
+}
+
+// getGame returns the state of a single game
+func (s *Server) getGame(w http.ResponseWriter, id string) {
+	hg, err := s.findGame(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, hg.snapshot())
+}
+
+// playMove plays the move in the request body for the current player of a game and returns the new state of the game
+func (s *Server) playMove(w http.ResponseWriter, r *http.Request, id string) {
+	hg, err := s.findGame(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	var req moveRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// the move and the state returned have to be read under the same lock so that no other move can be played in between
+	hg.mu.Lock()
+	err = hg.game.Play(req.Position)
+	res := hg.response()
+	hg.mu.Unlock()
+
+	if errors.Is(err, game.ErrGameOver) {
+		writeError(w, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// removeFinishedGames stops hosting every game that is over, the caller must hold the server's lock
+func (s *Server) removeFinishedGames() {
+	for id, hg := range s.games {
+		if hg.snapshot().Status != game.InProgress.String() {
+			delete(s.games, id)
+		}
+	}
+}
+
+// findGame returns the hosted game with the id
+func (s *Server) findGame(id string) (*hostedGame, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	hg, ok := s.games[id]
+	if !ok {
+		return nil, ErrGameNotFound
+	}
+
+	return hg, nil
+}
+
+// snapshot returns the state of the hosted game while holding its lock
+func (hg *hostedGame) snapshot() gameResponse {
+	hg.mu.Lock()
+	defer hg.mu.Unlock()
+
+	return hg.response()
+}
+
+// response returns the state of the hosted game, the caller must hold the game's lock
+func (hg *hostedGame) response() gameResponse {
+	g := hg.game
+	b := g.Board()
+
+	res := gameResponse{
+		ID:            hg.id,
+		Rows:          b.Rows(),
+		Cols:          b.Cols(),
+		WinCount:      b.WinCount,
+		Gravity:       b.Gravity,
+		Misere:        b.Misere,
+		Boxes:         make([][]string, b.Rows()),
+		Players:       make([]playerResponse, 0, len(g.Players())),
+		CurrentPlayer: g.CurrentPlayer().GetName(),
+		Status:        g.Status().String(),
+		Moves:         len(b.History()),
+	}
+
+	for row := range b.Boxes {
+		res.Boxes[row] = make([]string, b.Cols())
+		for col := range b.Boxes[row] {
+			res.Boxes[row][col] = board.BoxContentFormats[b.Boxes[row][col]].Name
+		}
+	}
+
+	for _, p := range g.Players() {
+		res.Players = append(res.Players, playerResponse{p.GetName(), board.BoxContentFormats[p.GetSymbol()].Name})
+	}
+
+	if winner := g.Winner(); winner != nil {
+		res.Winner = winner.GetName()
+	}
+	if loser := g.Loser(); loser != nil {
+		res.Loser = loser.GetName()
+	}
+
+	for _, position := range g.WinningLine().Positions {
+		res.WinningLine = append(res.WinningLine, positionResponse{position.RowIdx, position.ColIdx})
+	}
+
+	return res
+}
+
+// writeJSON writes the body as JSON with the status code
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes the error as a JSON body with the status code
+func writeError(w http.ResponseWriter, statusCode int, err error) {
+	writeJSON(w, statusCode, errorResponse{err.Error()})
+}
@@ -0,0 +1,341 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// do sends the request to the server and decodes the JSON response into the body
+func do(t *testing.T, s *Server, method string, path string, content string, body interface{}) int {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(content))
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if body != nil {
+		if err := json.NewDecoder(rec.Body).Decode(body); err != nil {
+			t.Fatalf("unexpected error = %v, want %v", err, nil)
+		}
+	}
+
+	return rec.Code
+}
+
+func TestCreateGame(t *testing.T) {
+	type want struct {
+		statusCode int
+		rows       int
+		cols       int
+		players    []playerResponse
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    want
+	}{
+		{
+			"creates a square game with default players",
+			`{"dimension": 3, "winCount": 3}`,
+			want{
+				http.StatusCreated,
+				3,
+				3,
+				[]playerResponse{{"Player 1", "x"}, {"Player 2", "o"}},
+			},
+		},
+		{
+			"creates a rectangular game with named players",
+			`{"rows": 6, "cols": 7, "winCount": 4, "gravity": true, "players": ["amos", "ben", "cal"]}`,
+			want{
+				http.StatusCreated,
+				6,
+				7,
+				[]playerResponse{{"amos", "x"}, {"ben", "o"}, {"cal", "triangle"}},
+			},
+		},
+		{
+			"returns bad request when the win count does not fit the board",
+			`{"dimension": 3, "winCount": 4}`,
+			want{
+				http.StatusBadRequest,
+				0,
+				0,
+				nil,
+			},
+		},
+		{
+			"returns bad request when there are too many players",
+			`{"dimension": 5, "winCount": 3, "players": ["a", "b", "c", "d", "e"]}`,
+			want{
+				http.StatusBadRequest,
+				0,
+				0,
+				nil,
+			},
+		},
+		{
+			"returns bad request when the board has too many boxes",
+			`{"rows": 100000, "cols": 100000, "winCount": 3}`,
+			want{
+				http.StatusBadRequest,
+				0,
+				0,
+				nil,
+			},
+		},
+		{
+			"returns bad request when the body is too large",
+			`{"dimension": 3, "winCount": 3, "players": ["` + strings.Repeat("a", maxBodyBytes) + `"]}`,
+			want{
+				http.StatusBadRequest,
+				0,
+				0,
+				nil,
+			},
+		},
+		{
+			"returns bad request when the body is not JSON",
+			`dimension=3`,
+			want{
+				http.StatusBadRequest,
+				0,
+				0,
+				nil,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var res gameResponse
+			statusCode := do(t, NewServer(), http.MethodPost, "/games", test.content, &res)
+
+			if statusCode != test.want.statusCode {
+				t.Errorf("unexpected status code = %d, want %d", statusCode, test.want.statusCode)
+			}
+
+			if res.Rows != test.want.rows || res.Cols != test.want.cols {
+				t.Errorf("unexpected dimensions = %dx%d, want %dx%d", res.Rows, res.Cols, test.want.rows, test.want.cols)
+			}
+
+			if !reflect.DeepEqual(res.Players, test.want.players) {
+				t.Errorf("unexpected players = %v, want %v", res.Players, test.want.players)
+			}
+
+		})
+	}
+
+}
+
+func TestPlayMove(t *testing.T) {
+	type want struct {
+		statusCode  int
+		status      string
+		winner      string
+		winningLine []positionResponse
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		contents []string
+		want     want
+	}{
+		{
+			"plays moves until a player wins",
+			"/games/1/moves",
+			[]string{`{"position": 1}`, `{"position": 4}`, `{"position": 2}`, `{"position": 5}`, `{"position": 3}`},
+			want{
+				http.StatusOK,
+				"won",
+				"Player 1",
+				[]positionResponse{{0, 0}, {0, 1}, {0, 2}},
+			},
+		},
+		{
+			"returns bad request when the box is occupied",
+			"/games/1/moves",
+			[]string{`{"position": 5}`, `{"position": 5}`},
+			want{
+				http.StatusBadRequest,
+				"",
+				"",
+				nil,
+			},
+		},
+		{
+			"returns conflict when the game is over",
+			"/games/1/moves",
+			[]string{`{"position": 1}`, `{"position": 4}`, `{"position": 2}`, `{"position": 5}`, `{"position": 3}`, `{"position": 6}`},
+			want{
+				http.StatusConflict,
+				"",
+				"",
+				nil,
+			},
+		},
+		{
+			"returns not found when the game does not exist",
+			"/games/2/moves",
+			[]string{`{"position": 1}`},
+			want{
+				http.StatusNotFound,
+				"",
+				"",
+				nil,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := NewServer()
+			do(t, s, http.MethodPost, "/games", `{"dimension": 3, "winCount": 3}`, nil)
+
+			var statusCode int
+			var res gameResponse
+			for _, content := range test.contents {
+				res = gameResponse{}
+				statusCode = do(t, s, http.MethodPost, test.path, content, &res)
+			}
+
+			if statusCode != test.want.statusCode {
+				t.Errorf("unexpected status code = %d, want %d", statusCode, test.want.statusCode)
+			}
+
+			if res.Status != test.want.status {
+				t.Errorf("unexpected status = %s, want %s", res.Status, test.want.status)
+			}
+
+			if res.Winner != test.want.winner {
+				t.Errorf("unexpected winner = %s, want %s", res.Winner, test.want.winner)
+			}
+
+			if !reflect.DeepEqual(res.WinningLine, test.want.winningLine) {
+				t.Errorf("unexpected winning line = %v, want %v", res.WinningLine, test.want.winningLine)
+			}
+
+		})
+	}
+
+}
+
+func TestGetAndListGames(t *testing.T) {
+	s := NewServer()
+	do(t, s, http.MethodPost, "/games", `{"dimension": 3, "winCount": 3}`, nil)
+	do(t, s, http.MethodPost, "/games", `{"dimension": 3, "winCount": 3}`, nil)
+	do(t, s, http.MethodPost, "/games", `{"dimension": 3, "winCount": 3}`, nil)
+
+	// the second game is finished so it is no longer listed
+	for _, position := range []string{"1", "4", "2", "5", "3"} {
+		do(t, s, http.MethodPost, "/games/2/moves", `{"position": `+position+`}`, nil)
+	}
+
+	var games []gameResponse
+	if statusCode := do(t, s, http.MethodGet, "/games", "", &games); statusCode != http.StatusOK {
+		t.Errorf("unexpected status code = %d, want %d", statusCode, http.StatusOK)
+	}
+
+	var ids []string
+	for _, g := range games {
+		ids = append(ids, g.ID)
+	}
+
+	wantIDs := []string{"1", "3"}
+	if !reflect.DeepEqual(ids, wantIDs) {
+		t.Errorf("unexpected ids = %v, want %v", ids, wantIDs)
+	}
+
+	var res gameResponse
+	if statusCode := do(t, s, http.MethodGet, "/games/2", "", &res); statusCode != http.StatusOK {
+		t.Errorf("unexpected status code = %d, want %d", statusCode, http.StatusOK)
+	}
+
+	wantBoxes := [][]string{{"x", "x", "x"}, {"o", "o", ""}, {"", "", ""}}
+	if !reflect.DeepEqual(res.Boxes, wantBoxes) {
+		t.Errorf("unexpected boxes = %v, want %v", res.Boxes, wantBoxes)
+	}
+
+	if statusCode := do(t, s, http.MethodGet, "/games/4", "", nil); statusCode != http.StatusNotFound {
+		t.Errorf("unexpected status code = %d, want %d", statusCode, http.StatusNotFound)
+	}
+
+	if statusCode := do(t, s, http.MethodDelete, "/games/1", "", nil); statusCode != http.StatusMethodNotAllowed {
+		t.Errorf("unexpected status code = %d, want %d", statusCode, http.StatusMethodNotAllowed)
+	}
+
+}
+
+func TestTooManyGames(t *testing.T) {
+	s := NewServer()
+	for i := 0; i < maxGames; i++ {
+		if statusCode := do(t, s, http.MethodPost, "/games", `{"dimension": 1, "winCount": 1}`, nil); statusCode != http.StatusCreated {
+			t.Fatalf("unexpected status code = %d, want %d", statusCode, http.StatusCreated)
+		}
+	}
+
+	if statusCode := do(t, s, http.MethodPost, "/games", `{"dimension": 1, "winCount": 1}`, nil); statusCode != http.StatusServiceUnavailable {
+		t.Errorf("unexpected status code = %d, want %d", statusCode, http.StatusServiceUnavailable)
+	}
+
+	// finishing a game makes room for a new one, the finished game is no longer hosted
+	do(t, s, http.MethodPost, "/games/1/moves", `{"position": 1}`, nil)
+
+	var res gameResponse
+	if statusCode := do(t, s, http.MethodPost, "/games", `{"dimension": 1, "winCount": 1}`, &res); statusCode != http.StatusCreated {
+		t.Errorf("unexpected status code = %d, want %d", statusCode, http.StatusCreated)
+	}
+
+	wantID := strconv.Itoa(maxGames + 1)
+	if res.ID != wantID {
+		t.Errorf("unexpected id = %s, want %s", res.ID, wantID)
+	}
+
+	if statusCode := do(t, s, http.MethodGet, "/games/1", "", nil); statusCode != http.StatusNotFound {
+		t.Errorf("unexpected status code = %d, want %d", statusCode, http.StatusNotFound)
+	}
+
+}
+
+func TestConcurrentMoves(t *testing.T) {
+	s := NewServer()
+	do(t, s, http.MethodPost, "/games", `{"dimension": 5, "winCount": 5}`, nil)
+
+	// every box is requested once by its own goroutine, so every move lands as long as the game is not won
+	var wg sync.WaitGroup
+	for position := 1; position <= 25; position++ {
+		wg.Add(1)
+		go func(position int) {
+			defer wg.Done()
+
+			req := httptest.NewRequest(http.MethodPost, "/games/1/moves", strings.NewReader(`{"position": `+strconv.Itoa(position)+`}`))
+			s.ServeHTTP(httptest.NewRecorder(), req)
+		}(position)
+	}
+	wg.Wait()
+
+	var res gameResponse
+	do(t, s, http.MethodGet, "/games/1", "", &res)
+
+	filled := 0
+	for _, row := range res.Boxes {
+		for _, box := range row {
+			if box != "" {
+				filled++
+			}
+		}
+	}
+
+	if filled != res.Moves {
+		t.Errorf("unexpected filled boxes = %d, want %d", filled, res.Moves)
+	}
+
+}
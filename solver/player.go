@@ -0,0 +1,63 @@
+package solver
+
+import (
+	"context"
+
+	"github.com/dev-amos/tictactoe/board"
+	"github.com/dev-amos/tictactoe/player"
+)
+
+type computerPlayer struct {
+	name   string
+	symbol board.BoxContent
+	solver *Solver
+}
+
+// NewPlayerParams defines the structure for the parameters needed to create a computer-controlled player that plays perfectly
+type NewPlayerParams struct {
+	Name   string
+	Symbol board.BoxContent
+}
+
+// NewPlayer creates a computer-controlled player that solves the board before every move, so it never loses a game that can be saved
+// the player can only play games between x and o, and the positions it has searched are kept from one move to the next.
+func NewPlayer(params NewPlayerParams) player.Computer {
+	return computerPlayer{
+		name:   params.Name,
+		symbol: params.Symbol,
+		solver: NewSolver(),
+	}
+}
+
+// GetName returns name of player.
+func (cp computerPlayer) GetName() string {
+	return cp.name
+}
+
+// GetSymbol returns symbol used by player to fill the boxes in tic tac toe
+func (cp computerPlayer) GetSymbol() board.BoxContent {
+	return cp.symbol
+}
+
+// GetLevel returns the perfect level, which is the only level the player plays at
+func (cp computerPlayer) GetLevel() player.Level {
+	return player.Perfect
+}
+
+// ChooseBox returns the numbered position of the box that wins the quickest, or loses the slowest when the game cannot be won
+// on a board with gravity the numbered column to drop the symbol into is returned instead
+// the context is not checked, a move is only known to be perfect once the board is solved, which is why the player is only given small boards
+func (cp computerPlayer) ChooseBox(_ context.Context, b *board.Board) (int, error) {
+	searchBoard := b.Clone()
+
+	best, err := cp.solver.BestMove(SolveParams{Board: searchBoard, ToMove: cp.symbol})
+	if err != nil {
+		return 0, err
+	}
+
+	if b.Gravity {
+		return best.Position.ColIdx + 1, nil
+	}
+
+	return best.Position.RowIdx*b.Cols() + best.Position.ColIdx + 1, nil
+}
@@ -0,0 +1,69 @@
+package solver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dev-amos/tictactoe/board"
+)
+
+func TestChooseBox(t *testing.T) {
+	type want struct {
+		err error
+		box int
+	}
+
+	tests := []struct {
+		name     string
+		position string
+		want     want
+	}{
+		{
+			"completes its own row instead of blocking",
+			"3x3 3 xx1/oo1/3 x",
+			want{nil, 3},
+		},
+		{
+			"takes the centre when the opponent has taken a corner",
+			"3x3 3 x2/3/3 o",
+			want{nil, 5},
+		},
+		{
+			"blocks the opponent's diagonal",
+			"3x3 3 o2/1ox/x2 x",
+			want{nil, 9},
+		},
+		{
+			"returns the column to block three in a row with gravity",
+			"4x4 3 4/4/1x2/xoo1 x g",
+			want{nil, 4},
+		},
+		{
+			"returns error when the game is already over",
+			"3x3 3 xxx/oo1/3 x",
+			want{ErrGameOver, 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, toMove, err := board.ParsePosition(test.position)
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			p := NewPlayer(NewPlayerParams{Name: "computer", Symbol: toMove})
+
+			gotBox, err := p.ChooseBox(context.Background(), b)
+			if err != test.want.err {
+				t.Errorf("unexpected error = %v, want %v", err, test.want.err)
+			}
+
+			if gotBox != test.want.box {
+				t.Errorf("unexpected box = %d, want %d", gotBox, test.want.box)
+			}
+
+		})
+	}
+
+}
@@ -0,0 +1,410 @@
+// Package solver works out the game-theoretic value of tic tac toe positions between two players with perfect play
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/dev-amos/tictactoe/board"
+)
+
+var (
+	ErrUnsupportedPosition = errors.New("solver only supports positions between the x and o players")
+	ErrGameOver            = errors.New("position is already decided and has no moves to solve")
+)
+
+// oToMoveKey is mixed into the hash of a position when o is to move, so that the same boxes with a different player to move are stored apart
+const oToMoveKey = 0x5bd1e9955bd1e995
+
+// mateScore is the score of a position won on the next move, every move further away from the end of the game lowers it by one
+const mateScore = 1 << 20
+
+// Outcome is the result of a game with perfect play from the point of view of the player to move
+type Outcome int
+
+// Results of a game with perfect play
+const (
+	Draw Outcome = iota // Neither player can force a win
+	Win                 // Player to move can force a win
+	Loss                // Opponent can force a win whatever the player to move does
+)
+
+// String returns the readable name of an outcome
+func (o Outcome) String() string {
+	switch o {
+	case Draw:
+		return "draw"
+	case Win:
+		return "win"
+	case Loss:
+		return "loss"
+	default:
+		return "unknown"
+	}
+}
+
+// Value is the outcome of a position with perfect play and how many moves, counting the moves of both players, are left until the game ends
+// the winner ends the game as quickly as possible and the loser holds it off for as long as possible
+type Value struct {
+	Outcome  Outcome
+	Distance int
+}
+
+// String returns the value in words, such as "win in 3"
+func (v Value) String() string {
+	if v.Outcome == Draw {
+		return v.Outcome.String()
+	}
+
+	return fmt.Sprintf("%s in %d", v.Outcome, v.Distance)
+}
+
+// MoveValue is a move of the player to move and the value of the position for that player once the move is made
+type MoveValue struct {
+	Position board.Position
+	Value    Value
+}
+
+// boundType tells how a score stored in the transposition table relates to the real score of the position
+type boundType int
+
+const (
+	exact      boundType = iota // Score is the real score
+	lowerBound                  // Real score is at least the score, the search was cut off by beta
+	upperBound                  // Real score is at most the score, no move reached alpha
+)
+
+// entry is a position stored in the transposition table
+type entry struct {
+	score int
+	bound boundType
+	best  board.Position // best move on the canonical form of the board
+}
+
+// Solver searches positions with negamax and alpha-beta pruning, remembering the positions it has searched in a transposition table
+// positions are stored by the hash of their canonical form, so that a position and its symmetries are only searched once
+// a solver can be reused for several positions of the same game so that the positions already searched are not searched again
+type Solver struct {
+	table map[uint64]entry
+	order []board.Position
+	nodes int
+}
+
+// SolveParams defines the structure for the parameters needed to solve a position
+type SolveParams struct {
+	Board  *board.Board
+	ToMove board.BoxContent
+}
+
+// NewSolver creates a solver with an empty transposition table
+func NewSolver() *Solver {
+	return &Solver{
+		table: make(map[uint64]entry),
+	}
+}
+
+// Nodes returns the number of positions searched so far
+func (s *Solver) Nodes() int {
+	return s.nodes
+}
+
+// Solve returns the value of the position for the player to move
+// the board is used to play out the moves searched and is left as it was given
+func (s *Solver) Solve(p SolveParams) (Value, error) {
+	if err := s.prepare(p); err != nil {
+		return Value{}, err
+	}
+
+	score := s.negamax(p.Board, p.ToMove, -mateScore, mateScore)
+
+	return scoreToValue(score), nil
+}
+
+// Analyze returns the value of every move the player to move can make, ordered from the best move to the worst
+func (s *Solver) Analyze(p SolveParams) ([]MoveValue, error) {
+	if err := s.prepare(p); err != nil {
+		return nil, err
+	}
+
+	var moveValues []MoveValue
+	for _, pos := range s.order {
+		if !p.Board.IsPlayable(pos.RowIdx, pos.ColIdx) {
+			continue
+		}
+
+		// every move is searched with the full window so that its value is exact and not only a bound
+		score := s.scoreMove(p.Board, p.ToMove, pos, -mateScore, mateScore)
+		moveValues = append(moveValues, MoveValue{pos, scoreToValue(score)})
+	}
+
+	sort.SliceStable(moveValues, func(i, j int) bool {
+		return valueToScore(moveValues[i].Value) > valueToScore(moveValues[j].Value)
+	})
+
+	return moveValues, nil
+}
+
+// BestMove returns the best move the player to move can make along with the value of the position
+// when several moves are as good the one nearest the centre is returned
+func (s *Solver) BestMove(p SolveParams) (MoveValue, error) {
+	if err := s.prepare(p); err != nil {
+		return MoveValue{}, err
+	}
+
+	var best MoveValue
+	found := false
+	alpha := -mateScore
+	for _, pos := range s.order {
+		if !p.Board.IsPlayable(pos.RowIdx, pos.ColIdx) {
+			continue
+		}
+
+		// a move that cannot beat the best move found so far only needs to be searched far enough to prove it
+		score := s.scoreMove(p.Board, p.ToMove, pos, alpha, mateScore)
+		if score > alpha || !found {
+			alpha = score
+			best = MoveValue{pos, scoreToValue(score)}
+			found = true
+		}
+	}
+
+	return best, nil
+}
+
+// prepare checks that the position can be solved, with the player to move matching the symbols on the board, and orders the boxes of the board to search
+func (s *Solver) prepare(p SolveParams) error {
+	if p.ToMove != board.X && p.ToMove != board.O {
+		return ErrUnsupportedPosition
+	}
+
+	for row := range p.Board.Boxes {
+		for col := range p.Board.Boxes[row] {
+			if content := p.Board.Boxes[row][col]; content != board.E && content != board.X && content != board.O {
+				return ErrUnsupportedPosition
+			}
+		}
+	}
+
+	switch status := p.Board.Status(); status.State {
+	case board.Invalid:
+		return status.Reason
+	case board.Won, board.Lost, board.Draw:
+		return ErrGameOver
+	}
+
+	if p.ToMove != NextToMove(p.Board) {
+		return board.ErrWrongPlayerToMove
+	}
+
+	if len(s.order) != p.Board.Rows()*p.Board.Cols() {
+		s.order = board.OrderByCentre(p.Board.Rows(), p.Board.Cols())
+	}
+
+	return nil
+}
+
+// NextToMove returns the symbol to move on the board in a game between x and o started by x, which are the only games the solver plays
+// x is to move when both have placed the same number of symbols and o when x has placed one more, E is returned for any other counts
+func NextToMove(b *board.Board) board.BoxContent {
+	counts := make(map[board.BoxContent]int, 2)
+	for row := range b.Boxes {
+		for col := range b.Boxes[row] {
+			counts[b.Boxes[row][col]]++
+		}
+	}
+
+	switch counts[board.X] - counts[board.O] {
+	case 0:
+		return board.X
+	case 1:
+		return board.O
+	default:
+		return board.E
+	}
+}
+
+// negamax returns the score of the position for the player to move, it is exact when it falls between alpha and beta and a bound otherwise
+func (s *Solver) negamax(b *board.Board, toMove board.BoxContent, alpha, beta int) int {
+	s.nodes++
+
+	key, symmetry := positionKey(b, toMove)
+	stored, found := s.table[key]
+	if found {
+		switch {
+		case stored.bound == exact:
+			return stored.score
+		case stored.bound == lowerBound && stored.score >= beta:
+			return stored.score
+		case stored.bound == upperBound && stored.score <= alpha:
+			return stored.score
+		}
+	}
+
+	// a move that wins on the spot cannot be bettered, so there is no need to search the other moves
+	if !b.Misere && s.hasWinningMove(b, toMove) {
+		return mateScore - 1
+	}
+
+	originalAlpha := alpha
+	best := -mateScore
+	var bestPosition board.Position
+
+	// the best move found the last time the position was searched is tried first, before the boxes in their usual order
+	for i := -1; i < len(s.order); i++ {
+		var pos board.Position
+		if i < 0 && !found {
+			continue
+		} else if i < 0 {
+			pos = b.TransformPosition(stored.best, symmetry.Inverse())
+		} else if pos = s.order[i]; found && b.TransformPosition(pos, symmetry) == stored.best {
+			continue
+		}
+
+		if !b.IsPlayable(pos.RowIdx, pos.ColIdx) {
+			continue
+		}
+
+		score := s.scoreMove(b, toMove, pos, alpha, beta)
+		if score > best {
+			best = score
+			bestPosition = pos
+		}
+		if score > alpha {
+			alpha = score
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+
+	bound := exact
+	if best <= originalAlpha {
+		bound = upperBound
+	} else if best >= beta {
+		bound = lowerBound
+	}
+	s.table[key] = entry{best, bound, b.TransformPosition(bestPosition, symmetry)}
+
+	return best
+}
+
+// scoreMove fills the box at pos with the symbol of the player to move, scores the resulting position for that player and takes the move back again
+func (s *Solver) scoreMove(b *board.Board, toMove board.BoxContent, pos board.Position, alpha, beta int) int {
+	insertBoxWithContentParams := board.InsertBoxWithContentParams{
+		RowIdx:  pos.RowIdx,
+		ColIdx:  pos.ColIdx,
+		Content: toMove,
+	}
+
+	b.SelectBox(insertBoxWithContentParams)
+	defer b.Undo()
+
+	checkForWinnerParams := board.CheckForWinnerParams{
+		PlayerSymbol: toMove,
+		RowIdx:       pos.RowIdx,
+		ColIdx:       pos.ColIdx,
+	}
+
+	// completing a line ends the game on this move, as a win or as a loss with misere rules
+	if b.CheckForWinner(checkForWinnerParams) {
+		if b.Misere {
+			return -(mateScore - 1)
+		}
+		return mateScore - 1
+	}
+
+	if !b.CanCompleteLine() {
+		return 0
+	}
+
+	// the opponent's score is turned around and moved one move further from the end of the game
+	// so the window is moved one move closer to the end of the game before it is handed to the opponent
+	return awayFromEnd(-s.negamax(b, opponent(toMove), -towardsEnd(beta), -towardsEnd(alpha)))
+}
+
+// awayFromEnd returns the score of a won or lost position one move further from the end of the game
+func awayFromEnd(score int) int {
+	switch {
+	case score > 0:
+		return score - 1
+	case score < 0:
+		return score + 1
+	default:
+		return 0
+	}
+}
+
+// towardsEnd returns the score of a won or lost position one move closer to the end of the game, it undoes awayFromEnd
+func towardsEnd(score int) int {
+	switch {
+	case score > 0:
+		return score + 1
+	case score < 0:
+		return score - 1
+	default:
+		return 0
+	}
+}
+
+// hasWinningMove checks if the player to move can complete a line with their next move
+func (s *Solver) hasWinningMove(b *board.Board, toMove board.BoxContent) bool {
+	for _, pos := range s.order {
+		if !b.IsPlayable(pos.RowIdx, pos.ColIdx) {
+			continue
+		}
+
+		b.Boxes[pos.RowIdx][pos.ColIdx] = toMove
+		won := b.CheckForWinner(board.CheckForWinnerParams{PlayerSymbol: toMove, RowIdx: pos.RowIdx, ColIdx: pos.ColIdx})
+		b.Boxes[pos.RowIdx][pos.ColIdx] = board.E
+
+		if won {
+			return true
+		}
+	}
+
+	return false
+}
+
+// positionKey returns the key of the position in the transposition table and the symmetry that turns the board into its canonical form
+func positionKey(b *board.Board, toMove board.BoxContent) (uint64, board.Symmetry) {
+	hash, symmetry := b.Canonical()
+	if toMove == board.O {
+		hash ^= oToMoveKey
+	}
+
+	return hash, symmetry
+}
+
+// opponent returns the symbol of the other player
+func opponent(symbol board.BoxContent) board.BoxContent {
+	if symbol == board.X {
+		return board.O
+	}
+
+	return board.X
+}
+
+// scoreToValue converts a score into the value it stands for
+func scoreToValue(score int) Value {
+	switch {
+	case score > 0:
+		return Value{Win, mateScore - score}
+	case score < 0:
+		return Value{Loss, mateScore + score}
+	default:
+		return Value{Draw, 0}
+	}
+}
+
+// valueToScore converts a value back into its score, so that values can be compared
+func valueToScore(v Value) int {
+	switch v.Outcome {
+	case Win:
+		return mateScore - v.Distance
+	case Loss:
+		return -(mateScore - v.Distance)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,170 @@
+package solver
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dev-amos/tictactoe/board"
+)
+
+func TestSolve(t *testing.T) {
+	type want struct {
+		err   error
+		value Value
+	}
+
+	tests := []struct {
+		name     string
+		position string
+		want     want
+	}{
+		{
+			"returns draw on an empty 3*3 board",
+			"3x3 3 3/3/3 x",
+			want{nil, Value{Draw, 0}},
+		},
+		{
+			"returns draw on an empty 3*3 board with misere rules",
+			"3x3 3 3/3/3 x m",
+			want{nil, Value{Draw, 0}},
+		},
+		{
+			"returns win in 5 on an empty 4*4 board with 3 in a line",
+			"4x4 3 4/4/4/4 x",
+			want{nil, Value{Win, 5}},
+		},
+		{
+			"returns win in 1 when a line can be completed",
+			"3x3 3 xx1/oo1/3 x",
+			want{nil, Value{Win, 1}},
+		},
+		{
+			"returns draw when o answers a corner with the centre",
+			"3x3 3 x2/1o1/3 x",
+			want{nil, Value{Draw, 0}},
+		},
+		{
+			"returns win in 5 for x when o has not answered a corner with the centre",
+			"3x3 3 xo1/3/3 x",
+			want{nil, Value{Win, 5}},
+		},
+		{
+			"returns win in 6 under misere rules when o can be forced to complete a line",
+			"3x3 3 1x1/1o1/3 x m",
+			want{nil, Value{Win, 6}},
+		},
+		{
+			"returns error with a third player's symbol on the board",
+			"4x4 3 t3/4/4/4 x",
+			want{ErrUnsupportedPosition, Value{}},
+		},
+		{
+			"returns error when the position has already been won",
+			"3x3 3 xxx/oo1/3 o",
+			want{ErrGameOver, Value{}},
+		},
+		{
+			"returns error when the position cannot be reached",
+			"3x3 3 oo1/1o1/2x x",
+			want{board.ErrImpossibleCounts, Value{}},
+		},
+		{
+			"returns error when o is to move after as many symbols as x",
+			"3x3 3 xo1/3/3 o",
+			want{board.ErrWrongPlayerToMove, Value{}},
+		},
+		{
+			"returns error when x is to move in a game started by o",
+			"3x3 3 oo1/xx1/o2 x",
+			want{board.ErrWrongPlayerToMove, Value{}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, toMove, err := board.ParsePosition(test.position)
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			value, err := NewSolver().Solve(SolveParams{Board: b, ToMove: toMove})
+			if err != test.want.err {
+				t.Errorf("unexpected error = %v, want %v", err, test.want.err)
+			}
+
+			if value != test.want.value {
+				t.Errorf("unexpected value = %v, want %v", value, test.want.value)
+			}
+
+			if got := b.FormatPosition(toMove); got != test.position {
+				t.Errorf("unexpected position after solving = %s, want %s", got, test.position)
+			}
+
+		})
+	}
+
+}
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name     string
+		position string
+		want     []MoveValue
+	}{
+		{
+			"orders the winning move before the block and the losing moves",
+			"3x3 3 xx1/oo1/3 x",
+			[]MoveValue{
+				{board.Position{RowIdx: 0, ColIdx: 2}, Value{Win, 1}},
+				{board.Position{RowIdx: 1, ColIdx: 2}, Value{Draw, 0}},
+				{board.Position{RowIdx: 2, ColIdx: 1}, Value{Loss, 2}},
+				{board.Position{RowIdx: 2, ColIdx: 0}, Value{Loss, 2}},
+				{board.Position{RowIdx: 2, ColIdx: 2}, Value{Loss, 2}},
+			},
+		},
+		{
+			"orders the quicker win first",
+			"3x3 3 xx1/oo1/2x o",
+			[]MoveValue{
+				{board.Position{RowIdx: 1, ColIdx: 2}, Value{Win, 1}},
+				{board.Position{RowIdx: 0, ColIdx: 2}, Value{Win, 3}},
+				{board.Position{RowIdx: 2, ColIdx: 1}, Value{Loss, 2}},
+				{board.Position{RowIdx: 2, ColIdx: 0}, Value{Loss, 2}},
+			},
+		},
+		{
+			"finds the centre as the only answer to a corner",
+			"3x3 3 x2/3/3 o",
+			[]MoveValue{
+				{board.Position{RowIdx: 1, ColIdx: 1}, Value{Draw, 0}},
+				{board.Position{RowIdx: 0, ColIdx: 1}, Value{Loss, 6}},
+				{board.Position{RowIdx: 1, ColIdx: 0}, Value{Loss, 6}},
+				{board.Position{RowIdx: 1, ColIdx: 2}, Value{Loss, 6}},
+				{board.Position{RowIdx: 2, ColIdx: 1}, Value{Loss, 6}},
+				{board.Position{RowIdx: 0, ColIdx: 2}, Value{Loss, 6}},
+				{board.Position{RowIdx: 2, ColIdx: 0}, Value{Loss, 6}},
+				{board.Position{RowIdx: 2, ColIdx: 2}, Value{Loss, 6}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, toMove, err := board.ParsePosition(test.position)
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			got, err := NewSolver().Analyze(SolveParams{Board: b, ToMove: toMove})
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("unexpected move values = %v, want %v", got, test.want)
+			}
+
+		})
+	}
+
+}
@@ -0,0 +1,425 @@
+// Package network lets a player take part in a tic tac toe game from another machine over a plain TCP connection
+//
+// The host runs the game and stays the only authority on the board, the joining side only renders what it is sent
+// and answers when it is asked for a move. Every message is a single line of space separated fields:
+//
+//	client -> host: NAME <name>                           sent once after connecting
+//	client -> host: PLAY <number>                         box, or column with gravity, chosen by the remote player
+//	host -> client: BOARD <rows> <cols> <win count> <gravity> <misere> <boxes> <last move>
+//	host -> client: TURN <symbol> <name>                  asks the remote player for a move
+//	host -> client: INVALID <reason>                      the last move sent could not be played
+//	host -> client: WIN <name>, LOSE <name>, DRAW         game results, the host closes the connection once the game is over
+//
+// gravity and misere are sent as 0 or 1, boxes are the rows of the board separated by / with one character per box
+// and last move is the row and col index of the last move joined by a comma, or - when no move has been made
+package network
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dev-amos/tictactoe/board"
+	"github.com/dev-amos/tictactoe/view"
+)
+
+var (
+	ErrUnexpectedMessage = errors.New("unexpected message received from connection")
+	ErrInvalidBoard      = errors.New("board received from connection is invalid")
+)
+
+// message types of the line based protocol
+const (
+	nameMessage    = "NAME"
+	playMessage    = "PLAY"
+	boardMessage   = "BOARD"
+	turnMessage    = "TURN"
+	invalidMessage = "INVALID"
+	winMessage     = "WIN"
+	loseMessage    = "LOSE"
+	drawMessage    = "DRAW"
+)
+
+// Host is a view for the machine running the game, it shows the game on a local view while one player takes part over the connection
+// the remote player is recognised by the symbol it plays with, every other player is asked for moves through the local view
+type Host struct {
+	local          view.View
+	conn           io.ReadWriter
+	reader         *bufio.Reader
+	remoteName     string
+	remoteSymbol   board.BoxContent
+	promptedRemote bool
+}
+
+// NewHostParams defines the structure for the parameters needed to host a game for a remote player
+type NewHostParams struct {
+	Local        view.View
+	Conn         io.ReadWriter
+	RemoteSymbol board.BoxContent
+}
+
+// NewHost waits for the remote player on the connection to introduce itself and returns the view that hosts the game
+func NewHost(p NewHostParams) (*Host, error) {
+	h := &Host{
+		local:        p.Local,
+		conn:         p.Conn,
+		reader:       bufio.NewReader(p.Conn),
+		remoteSymbol: p.RemoteSymbol,
+	}
+
+	messageType, content, err := readMessage(h.reader)
+	if err != nil {
+		return nil, err
+	} else if messageType != nameMessage {
+		return nil, ErrUnexpectedMessage
+	}
+
+	h.remoteName = content
+
+	return h, nil
+}
+
+// RemoteName returns the name the remote player has joined with
+func (h *Host) RemoteName() string {
+	return h.remoteName
+}
+
+// PrintBoard prints the board on the local view and sends it to the remote player
+func (h *Host) PrintBoard(b board.Board) {
+	h.local.PrintBoard(b)
+	h.send(boardMessage, encodeBoard(&b))
+}
+
+// GetDimensions gets the dimensions of the board from the local view, the host sets up the game
+func (h *Host) GetDimensions() (int, int, error) {
+	return h.local.GetDimensions()
+}
+
+// GetWinCount gets the number of boxes in a line needed to win from the local view, the host sets up the game
+func (h *Host) GetWinCount() (int, error) {
+	return h.local.GetWinCount()
+}
+
+// GetNumberOfPlayers gets the number of players from the local view, the host sets up the game
+func (h *Host) GetNumberOfPlayers(maxPlayers int) (int, error) {
+	return h.local.GetNumberOfPlayers(maxPlayers)
+}
+
+// GetUserName gets the nth player name from the local view
+func (h *Host) GetUserName(playerCount int) (string, error) {
+	return h.local.GetUserName(playerCount)
+}
+
+// GetSeat asks the local view who plays the nth player's seat
+func (h *Host) GetSeat(p view.GetSeatParams) (view.Seat, error) {
+	return h.local.GetSeat(p)
+}
+
+// GetUserToSelectBox gets the player to choose a numbered box, from the connection when it is the remote player's turn
+func (h *Host) GetUserToSelectBox(p view.GetUserToSelectBoxParams) (int, error) {
+	if p.PlayerSymbol != h.remoteSymbol {
+		h.promptedRemote = false
+		return askLocal(h.local, false, p)
+	}
+
+	return h.askRemote(p)
+}
+
+// GetUserToSelectColumn gets the player to choose a numbered column, from the connection when it is the remote player's turn
+func (h *Host) GetUserToSelectColumn(p view.GetUserToSelectBoxParams) (int, error) {
+	if p.PlayerSymbol != h.remoteSymbol {
+		h.promptedRemote = false
+		return askLocal(h.local, true, p)
+	}
+
+	return h.askRemote(p)
+}
+
+// DeclareInvalidMove tells the player who has chosen the move why it cannot be played
+func (h *Host) DeclareInvalidMove(reason error) {
+	if h.promptedRemote {
+		h.send(invalidMessage, reason.Error())
+		return
+	}
+
+	h.local.DeclareInvalidMove(reason)
+}
+
+// DeclareWinner announces the winner on both the local view and the connection
+func (h *Host) DeclareWinner(playerName string) {
+	h.local.DeclareWinner(playerName)
+	h.send(winMessage, playerName)
+}
+
+// DeclareLoser announces the player who has lost under misere rules on both the local view and the connection
+func (h *Host) DeclareLoser(playerName string) {
+	h.local.DeclareLoser(playerName)
+	h.send(loseMessage, playerName)
+}
+
+// DeclareDraw announces the draw on both the local view and the connection
+func (h *Host) DeclareDraw() {
+	h.local.DeclareDraw()
+	h.send(drawMessage, "")
+}
+
+// askRemote asks the remote player for a move and waits for the number chosen, anything that is not a number is asked for again
+func (h *Host) askRemote(p view.GetUserToSelectBoxParams) (int, error) {
+	h.promptedRemote = true
+
+	for {
+		h.send(turnMessage, fmt.Sprintf("%c %s", board.BoxContentFormats[p.PlayerSymbol].Character, p.PlayerName))
+
+		messageType, content, err := readMessage(h.reader)
+		if err != nil {
+			return 0, err
+		} else if messageType != playMessage {
+			return 0, ErrUnexpectedMessage
+		}
+
+		choice, err := strconv.Atoi(content)
+		if err != nil {
+			h.send(invalidMessage, fmt.Sprintf("'%s' is not a number", content))
+			continue
+		}
+
+		return choice, nil
+	}
+}
+
+// send writes a message to the connection, a broken connection shows up as an error on the next read
+func (h *Host) send(messageType, content string) {
+	writeMessage(h.conn, messageType, content)
+}
+
+// JoinParams defines the structure for the parameters needed to join a game hosted on another machine
+type JoinParams struct {
+	Local view.View
+	Conn  io.ReadWriter
+	Name  string
+}
+
+// Join takes part in the game hosted on the other end of the connection, showing it on the local view until the host closes the connection
+func Join(p JoinParams) error {
+	reader := bufio.NewReader(p.Conn)
+
+	if err := writeMessage(p.Conn, nameMessage, p.Name); err != nil {
+		return err
+	}
+
+	var gravity, gameOver bool
+	for {
+		messageType, content, err := readMessage(reader)
+		if err == io.EOF && gameOver {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		switch messageType {
+		case boardMessage:
+			b, err := decodeBoard(content)
+			if err != nil {
+				return err
+			}
+			gravity = b.Gravity
+			p.Local.PrintBoard(*b)
+
+		case turnMessage:
+			fields := strings.SplitN(content, " ", 2)
+			if len(fields) != 2 || len(fields[0]) != 1 {
+				return ErrUnexpectedMessage
+			}
+
+			symbol, ok := board.SymbolFromCharacter(fields[0][0])
+			if !ok {
+				return ErrUnexpectedMessage
+			}
+
+			choice, err := askLocal(p.Local, gravity, view.GetUserToSelectBoxParams{
+				PlayerName:   fields[1],
+				PlayerSymbol: symbol,
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := writeMessage(p.Conn, playMessage, strconv.Itoa(choice)); err != nil {
+				return err
+			}
+
+		case invalidMessage:
+			p.Local.DeclareInvalidMove(errors.New(content))
+		case winMessage:
+			gameOver = true
+			p.Local.DeclareWinner(content)
+		case loseMessage:
+			gameOver = true
+			p.Local.DeclareLoser(content)
+		case drawMessage:
+			gameOver = true
+			p.Local.DeclareDraw()
+		default:
+			return ErrUnexpectedMessage
+		}
+	}
+}
+
+// askLocal gets the local player to choose a box, or a column with gravity
+// moves cannot be taken back on either side of a networked game, the player on the other side would lose moves without agreeing to it
+func askLocal(v view.View, gravity bool, p view.GetUserToSelectBoxParams) (int, error) {
+	for {
+		var choice int
+		var err error
+		if gravity {
+			choice, err = v.GetUserToSelectColumn(p)
+		} else {
+			choice, err = v.GetUserToSelectBox(p)
+		}
+
+		if errors.Is(err, view.ErrUndoRequested) || errors.Is(err, view.ErrRedoRequested) {
+			v.DeclareInvalidMove(errors.New("moves cannot be taken back in a networked game"))
+			continue
+		}
+
+		return choice, err
+	}
+}
+
+// readMessage reads a single line from the connection and splits it into its message type and content
+func readMessage(r *bufio.Reader) (string, string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", "", err
+	}
+
+	fields := strings.SplitN(strings.TrimSpace(line), " ", 2)
+	if len(fields) == 1 {
+		return fields[0], "", nil
+	}
+
+	return fields[0], fields[1], nil
+}
+
+// writeMessage writes a single line made of the message type and content to the connection
+func writeMessage(w io.Writer, messageType, content string) error {
+	line := messageType
+	if content != "" {
+		line += " " + content
+	}
+
+	_, err := io.WriteString(w, line+"\n")
+
+	return err
+}
+
+// encodeBoard returns the content of a BOARD message for the board
+func encodeBoard(b *board.Board) string {
+	rows := make([]string, 0, b.Rows())
+	for row := range b.Boxes {
+		var sb strings.Builder
+		for col := range b.Boxes[row] {
+			sb.WriteByte(board.BoxContentFormats[b.Boxes[row][col]].Character)
+		}
+		rows = append(rows, sb.String())
+	}
+
+	lastMove := "-"
+	if history := b.History(); len(history) > 0 {
+		move := history[len(history)-1]
+		lastMove = fmt.Sprintf("%d,%d", move.RowIdx, move.ColIdx)
+	}
+
+	return fmt.Sprintf("%d %d %d %d %d %s %s", b.Rows(), b.Cols(), b.WinCount, boolToFlag(b.Gravity), boolToFlag(b.Misere), strings.Join(rows, "/"), lastMove)
+}
+
+// decodeBoard creates the board described by the content of a BOARD message
+// the last move is filled in last so that the receiving view can tell which move has ended the game
+func decodeBoard(content string) (*board.Board, error) {
+	fields := strings.Fields(content)
+	if len(fields) != 7 {
+		return nil, ErrInvalidBoard
+	}
+
+	numbers := make([]int, 5)
+	for i := range numbers {
+		number, err := strconv.Atoi(fields[i])
+		if err != nil {
+			return nil, ErrInvalidBoard
+		}
+		numbers[i] = number
+	}
+
+	// the rows are checked against the dimensions before any box is allocated, so that a host cannot claim a board bigger than it sends
+	rows := strings.Split(fields[5], "/")
+	if len(rows) != numbers[0] {
+		return nil, ErrInvalidBoard
+	}
+
+	boxes := make([][]board.BoxContent, len(rows))
+	for row := range rows {
+		if len(rows[row]) != numbers[1] {
+			return nil, ErrInvalidBoard
+		}
+
+		boxes[row] = make([]board.BoxContent, len(rows[row]))
+		for col := range boxes[row] {
+			content, ok := board.SymbolFromCharacter(rows[row][col])
+			if !ok && rows[row][col] != board.BoxContentFormats[board.E].Character {
+				return nil, ErrInvalidBoard
+			}
+			boxes[row][col] = content
+		}
+	}
+
+	// the box of the last move is left empty on the new board and filled through SelectBox, so that it is the board's last move
+	var last board.InsertBoxWithContentParams
+	hasLastMove := fields[6] != "-"
+	if hasLastMove {
+		if _, err := fmt.Sscanf(fields[6], "%d,%d", &last.RowIdx, &last.ColIdx); err != nil {
+			return nil, ErrInvalidBoard
+		}
+		if last.RowIdx < 0 || last.RowIdx >= len(boxes) || last.ColIdx < 0 || last.ColIdx >= len(boxes[last.RowIdx]) {
+			return nil, ErrInvalidBoard
+		}
+
+		last.Content = boxes[last.RowIdx][last.ColIdx]
+		boxes[last.RowIdx][last.ColIdx] = board.E
+	}
+
+	newBoardWithBoxesParams := board.NewBoardWithBoxesParams{
+		Rows:     numbers[0],
+		Cols:     numbers[1],
+		WinCount: numbers[2],
+		Gravity:  numbers[3] == 1,
+		Misere:   numbers[4] == 1,
+		Boxes:    boxes,
+	}
+
+	b, err := board.NewBoardWithBoxes(newBoardWithBoxesParams)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasLastMove {
+		return b, nil
+	}
+
+	if err := b.SelectBox(last); err != nil {
+		return nil, ErrInvalidBoard
+	}
+
+	return b, nil
+}
+
+// boolToFlag converts a rule setting into the 0 or 1 sent in a message
+func boolToFlag(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,314 @@
+package network
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dev-amos/tictactoe/board"
+	"github.com/dev-amos/tictactoe/game"
+	"github.com/dev-amos/tictactoe/player"
+	"github.com/dev-amos/tictactoe/player/real"
+	"github.com/dev-amos/tictactoe/view"
+)
+
+// scriptedView is a view that answers every prompt for a move from a fixed list and records what it is shown
+type scriptedView struct {
+	view.View
+	moves        []int
+	requests     []error // returned instead of a move until there are none left
+	lastBoard    board.Board
+	invalidMoves []string
+	winner       string
+	draw         bool
+}
+
+func (sv *scriptedView) PrintBoard(b board.Board) {
+	sv.lastBoard = b
+}
+
+func (sv *scriptedView) GetUserToSelectBox(p view.GetUserToSelectBoxParams) (int, error) {
+	if len(sv.requests) > 0 {
+		err := sv.requests[0]
+		sv.requests = sv.requests[1:]
+		return 0, err
+	}
+
+	move := sv.moves[0]
+	sv.moves = sv.moves[1:]
+
+	return move, nil
+}
+
+func (sv *scriptedView) DeclareInvalidMove(reason error) {
+	sv.invalidMoves = append(sv.invalidMoves, reason.Error())
+}
+
+func (sv *scriptedView) DeclareWinner(playerName string) {
+	sv.winner = playerName
+}
+
+func (sv *scriptedView) DeclareDraw() {
+	sv.draw = true
+}
+
+func TestHostAndJoinOnLoopback(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error = %v, want %v", err, nil)
+	}
+	defer listener.Close()
+
+	// the remote player first picks the box the host has just filled, which the host has to reject
+	remoteView := &scriptedView{moves: []int{1, 4, 5}}
+	joinErr := make(chan error, 1)
+
+	go func() {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		if err != nil {
+			joinErr <- err
+			return
+		}
+		defer conn.Close()
+
+		joinParams := JoinParams{
+			Local: remoteView,
+			Conn:  conn,
+			Name:  "remote",
+		}
+		joinErr <- Join(joinParams)
+	}()
+
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatalf("unexpected error = %v, want %v", err, nil)
+	}
+
+	localView := &scriptedView{moves: []int{1, 2, 3}}
+	newHostParams := NewHostParams{
+		Local:        localView,
+		Conn:         conn,
+		RemoteSymbol: board.O,
+	}
+
+	host, err := NewHost(newHostParams)
+	if err != nil {
+		t.Fatalf("unexpected error = %v, want %v", err, nil)
+	}
+
+	if host.RemoteName() != "remote" {
+		t.Errorf("unexpected remote name = %s, want %s", host.RemoteName(), "remote")
+	}
+
+	b, _ := board.NewBoard(board.NewBoardParams{WinCount: 3, Rows: 3, Cols: 3})
+	newGameParams := game.NewGameParams{
+		Board: b,
+		Players: []player.Player{
+			real.NewPlayer(real.NewPlayerParams{Name: "local", Symbol: board.X}),
+			real.NewPlayer(real.NewPlayerParams{Name: host.RemoteName(), Symbol: board.O}),
+		},
+	}
+	g, _ := game.NewGame(newGameParams)
+
+	for g.Status() == game.InProgress {
+		host.PrintBoard(*g.Board())
+
+		getUserToSelectBoxParams := view.GetUserToSelectBoxParams{
+			PlayerName:   g.CurrentPlayer().GetName(),
+			PlayerSymbol: g.CurrentPlayer().GetSymbol(),
+		}
+
+		box, err := host.GetUserToSelectBox(getUserToSelectBoxParams)
+		if err != nil {
+			t.Fatalf("unexpected error = %v, want %v", err, nil)
+		}
+
+		if err := g.Play(box); err != nil {
+			host.DeclareInvalidMove(err)
+		}
+	}
+
+	host.PrintBoard(*g.Board())
+	host.DeclareWinner(g.Winner().GetName())
+	conn.Close()
+
+	if err := <-joinErr; err != nil {
+		t.Fatalf("unexpected join error = %v, want %v", err, nil)
+	}
+
+	if remoteView.winner != "local" {
+		t.Errorf("unexpected winner = %s, want %s", remoteView.winner, "local")
+	}
+
+	wantInvalidMoves := []string{board.ErrBoxOccupied.Error()}
+	if !reflect.DeepEqual(remoteView.invalidMoves, wantInvalidMoves) {
+		t.Errorf("unexpected invalid moves = %v, want %v", remoteView.invalidMoves, wantInvalidMoves)
+	}
+
+	if localView.invalidMoves != nil {
+		t.Errorf("unexpected invalid moves on host = %v, want %v", localView.invalidMoves, nil)
+	}
+
+	if !reflect.DeepEqual(remoteView.lastBoard.Boxes, b.Boxes) {
+		t.Errorf("unexpected Boxes = %v, want %v", remoteView.lastBoard.Boxes, b.Boxes)
+	}
+
+	if history := remoteView.lastBoard.History(); len(history) != 1 || history[0] != (board.Move{RowIdx: 0, ColIdx: 2, Content: board.X}) {
+		t.Errorf("unexpected last move = %v, want the winning move", history)
+	}
+
+}
+
+func TestHostRejectsTakingBackMoves(t *testing.T) {
+	conn := struct {
+		io.Reader
+		io.Writer
+	}{strings.NewReader("NAME remote\n"), &bytes.Buffer{}}
+
+	localView := &scriptedView{moves: []int{5}, requests: []error{view.ErrUndoRequested, view.ErrRedoRequested}}
+	newHostParams := NewHostParams{
+		Local:        localView,
+		Conn:         conn,
+		RemoteSymbol: board.O,
+	}
+
+	host, err := NewHost(newHostParams)
+	if err != nil {
+		t.Fatalf("unexpected error = %v, want %v", err, nil)
+	}
+
+	// the local player is asked again until a box is chosen, taking back the remote player's moves is not allowed
+	getUserToSelectBoxParams := view.GetUserToSelectBoxParams{
+		PlayerName:   "local",
+		PlayerSymbol: board.X,
+	}
+	box, err := host.GetUserToSelectBox(getUserToSelectBoxParams)
+
+	if err != nil {
+		t.Errorf("unexpected error = %v, want %v", err, nil)
+	}
+
+	if box != 5 {
+		t.Errorf("unexpected box = %d, want %d", box, 5)
+	}
+
+	if len(localView.invalidMoves) != 2 {
+		t.Errorf("unexpected invalid moves = %v, want %d of them", localView.invalidMoves, 2)
+	}
+
+}
+
+func TestDecodeBoard(t *testing.T) {
+	type want struct {
+		err   error
+		boxes [][]board.BoxContent
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    want
+	}{
+		{
+			"decodes a board with gravity and its last move",
+			"2 3 3 1 0 ..t/xos 0,2",
+			want{
+				nil,
+				[][]board.BoxContent{
+					{board.E, board.E, board.T},
+					{board.X, board.O, board.S},
+				},
+			},
+		},
+		{
+			"decodes a board without a last move",
+			"2 2 2 0 0 x./.o -",
+			want{
+				nil,
+				[][]board.BoxContent{
+					{board.X, board.E},
+					{board.E, board.O},
+				},
+			},
+		},
+		{
+			"returns error when a row is missing",
+			"2 3 3 0 0 xos -",
+			want{
+				ErrInvalidBoard,
+				nil,
+			},
+		},
+		{
+			"returns error without allocating the board when the dimensions are far bigger than the rows sent",
+			"1000000 1000000 3 0 0 xo. -",
+			want{
+				ErrInvalidBoard,
+				nil,
+			},
+		},
+		{
+			"returns error when a box holds an unknown symbol",
+			"1 3 3 0 0 x?o -",
+			want{
+				ErrInvalidBoard,
+				nil,
+			},
+		},
+		{
+			"returns error when the last move is outside of the board",
+			"1 3 3 0 0 xoo 1,0",
+			want{
+				ErrInvalidBoard,
+				nil,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := decodeBoard(test.content)
+
+			if !reflect.DeepEqual(err, test.want.err) {
+				t.Errorf("unexpected error = %v, want %v", err, test.want.err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(b.Boxes, test.want.boxes) {
+				t.Errorf("unexpected Boxes = %v, want %v", b.Boxes, test.want.boxes)
+			}
+
+			if gotContent := encodeBoard(b); gotContent != test.content {
+				t.Errorf("unexpected encoded board = %s, want %s", gotContent, test.content)
+			}
+
+			// the hash does not depend on the order the boxes are filled in, so the expected board is filled row by row without gravity
+			expectBoard, err := board.NewBoard(board.NewBoardParams{WinCount: b.WinCount, Rows: b.Rows(), Cols: b.Cols()})
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+			for row := range test.want.boxes {
+				for col, content := range test.want.boxes[row] {
+					if content == board.E {
+						continue
+					}
+					if err := expectBoard.SelectBox(board.InsertBoxWithContentParams{RowIdx: row, ColIdx: col, Content: content}); err != nil {
+						t.Fatalf("unexpected error = %v, want %v", err, nil)
+					}
+				}
+			}
+
+			if got, want := b.Hash(), expectBoard.Hash(); got != want {
+				t.Errorf("unexpected hash = %d, want %d", got, want)
+			}
+
+		})
+	}
+
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/dev-amos/tictactoe/board"
+	"github.com/dev-amos/tictactoe/player"
 	"github.com/dev-amos/tictactoe/view"
 )
 
@@ -16,12 +17,18 @@ type Terminal struct {
 }
 
 // PrintBoard prints out the tic tac toe's board on command line
+// when the last move has won the game, the symbols on the winning line are highlighted
+// with gravity the empty boxes are left blank and the columns are numbered below the board instead
 func (t Terminal) PrintBoard(b board.Board) {
 
 	var sb strings.Builder
+	winningBoxes := findWinningBoxes(&b)
 	var boxPosition int = 1
 
-	maxNumberOfBoxes := len(b.Boxes) * len(b.Boxes)
+	maxNumberOfBoxes := b.Rows() * b.Cols()
+	if b.Gravity {
+		maxNumberOfBoxes = b.Cols()
+	}
 	paddingSizeForEachDigitOnBox := digitsCount(maxNumberOfBoxes)
 	dashesPerBox := 4 + (paddingSizeForEachDigitOnBox - 1)
 
@@ -32,9 +39,14 @@ func (t Terminal) PrintBoard(b board.Board) {
 			sb.WriteString(" ")
 
 			boxContentStr := convertBoxContent(b.Boxes[row][col])
+			if winningBoxes[board.Position{RowIdx: row, ColIdx: col}] {
+				boxContentStr = highlightBoxContent(b.Boxes[row][col])
+			}
 
 			if boxContentStr != "" {
 				sb.WriteString(fmt.Sprintf("%*s", paddingSizeForEachDigitOnBox, boxContentStr))
+			} else if b.Gravity {
+				sb.WriteString(strings.Repeat(" ", paddingSizeForEachDigitOnBox))
 			} else {
 				sb.WriteString(fmt.Sprintf("%*s", paddingSizeForEachDigitOnBox, strconv.Itoa(boxPosition)))
 			}
@@ -49,15 +61,49 @@ func (t Terminal) PrintBoard(b board.Board) {
 
 		sb.WriteString("\n")
 
-		if row != len(b.Boxes)-1 {
+		if row != len(b.Boxes)-1 || b.Gravity {
 			fmt.Fprintln(&sb, horizontalDashLines.String())
 		}
 
 	}
 
+	if b.Gravity {
+		for col := 0; col < b.Cols(); col++ {
+			sb.WriteString(fmt.Sprintf(" %*d", paddingSizeForEachDigitOnBox, col+1))
+			if col < b.Cols()-1 {
+				sb.WriteString("  ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+
 	fmt.Println(sb.String())
 }
 
+// GetNumberOfPlayers gets the number of players joining the game from command line and returns it, defaulting to 2 when nothing is entered
+// anything that is not a number of players between 2 and the max players is asked for again
+func (t Terminal) GetNumberOfPlayers(maxPlayers int) (int, error) {
+	fmt.Printf("Enter number of players (2-%d):\n", maxPlayers)
+
+	for {
+		input, err := t.InputReader.ReadString('\n')
+		if err != nil {
+			return 0, err
+		}
+
+		input = strings.TrimSpace(input)
+		if input == "" {
+			return 2, nil
+		}
+
+		if numberOfPlayers, err := strconv.Atoi(input); err == nil && numberOfPlayers >= 2 && numberOfPlayers <= maxPlayers {
+			return numberOfPlayers, nil
+		}
+
+		fmt.Printf("'%s' is not a number of players between 2 and %d, choose again:\n", input, maxPlayers)
+	}
+}
+
 // GetUserName gets the nth player name from command line and returns it
 func (t Terminal) GetUserName(playerCount int) (string, error) {
 	fmt.Printf("Enter name for Player %d\n", playerCount)
@@ -71,27 +117,71 @@ func (t Terminal) GetUserName(playerCount int) (string, error) {
 	return name, nil
 }
 
-// GetDimensions gets the dimension size for the tic tac toe board form command line and returns it
-func (t Terminal) GetDimensions() (int, error) {
-	fmt.Println("Enter game dimensions for tictactoe:")
+// GetSeat asks from command line who plays the nth player's seat, a human or the computer at one of its levels, defaulting to a human when nothing is entered
+// the choice can be entered as its number or its name, any other input, such as a level that cannot be played in this game, is asked for again
+func (t Terminal) GetSeat(p view.GetSeatParams) (view.Seat, error) {
+	fmt.Printf("Who plays Player %d?\n", p.PlayerCount)
+	fmt.Println("  1) human")
+	for i, level := range p.Levels {
+		fmt.Printf("  %d) %s computer\n", i+2, level)
+	}
+
+	for {
+		input, err := t.InputReader.ReadString('\n')
+		if err != nil {
+			return view.Seat{}, err
+		}
+
+		input = strings.ToLower(strings.TrimSpace(input))
+		if input == "" || input == "1" || input == "human" {
+			return view.Seat{}, nil
+		}
+
+		if choice, err := strconv.Atoi(input); err == nil && choice >= 2 && choice < len(p.Levels)+2 {
+			return view.Seat{Computer: true, Level: p.Levels[choice-2]}, nil
+		}
+
+		if level, err := player.ParseLevel(strings.TrimSuffix(input, " computer")); err == nil && p.Offers(level) {
+			return view.Seat{Computer: true, Level: level}, nil
+		}
+
+		fmt.Printf("'%s' is not a choice, choose again:\n", input)
+	}
+}
+
+// GetDimensions gets the number of rows and columns for the tic tac toe board from command line and returns them
+// the dimensions are entered as rows x cols, such as 6x7, or as a single number for a square board
+func (t Terminal) GetDimensions() (int, int, error) {
+	fmt.Println("Enter game dimensions for tictactoe (e.g. 3 or 6x7):")
 
 	input, err := t.InputReader.ReadString('\n')
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 
-	input = strings.TrimSpace(input)
-	dimension, err := strconv.Atoi(input)
+	input = strings.ToLower(strings.TrimSpace(input))
+	dimensions := strings.SplitN(input, "x", 2)
+
+	rows, err := strconv.Atoi(strings.TrimSpace(dimensions[0]))
 	if err != nil {
-		return 0, err
+		return 0, 0, err
+	}
+
+	if len(dimensions) == 1 {
+		return rows, rows, nil
 	}
 
-	return dimension, nil
+	cols, err := strconv.Atoi(strings.TrimSpace(dimensions[1]))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return rows, cols, nil
 }
 
-// GetUserToSelectBox gets user to choose a numbered position on the tic tac toe board from the command line to select their move
-func (t Terminal) GetUserToSelectBox(p view.GetUserToSelectBoxParams) (int, error) {
-	fmt.Printf("%s, choose a box to place an '%s' into:\n", p.PlayerName, convertBoxContent(p.PlayerSymbol))
+// GetWinCount gets the number of boxes in a line a player needs to fill to win from command line and returns it
+func (t Terminal) GetWinCount() (int, error) {
+	fmt.Println("Enter number of boxes in a line needed to win:")
 
 	input, err := t.InputReader.ReadString('\n')
 	if err != nil {
@@ -99,12 +189,54 @@ func (t Terminal) GetUserToSelectBox(p view.GetUserToSelectBoxParams) (int, erro
 	}
 
 	input = strings.TrimSpace(input)
-	idxChoice, err := strconv.Atoi(input)
+	winCount, err := strconv.Atoi(input)
 	if err != nil {
 		return 0, err
 	}
 
-	return idxChoice, nil
+	return winCount, nil
+}
+
+// GetUserToSelectBox gets user to choose a numbered position on the tic tac toe board from the command line to select their move
+// the user can also ask to undo or redo a move instead, any other input that is not a number is asked for again
+func (t Terminal) GetUserToSelectBox(p view.GetUserToSelectBoxParams) (int, error) {
+	fmt.Printf("%s, choose a box to place an '%s' into (u to undo, r to redo):\n", p.PlayerName, convertBoxContent(p.PlayerSymbol))
+
+	return t.readChoice("box")
+}
+
+// GetUserToSelectColumn gets user to choose a numbered column on a board with gravity from the command line to drop their symbol into
+// the user can also ask to undo or redo a move instead, any other input that is not a number is asked for again
+func (t Terminal) GetUserToSelectColumn(p view.GetUserToSelectBoxParams) (int, error) {
+	fmt.Printf("%s, choose a column to drop an '%s' into (u to undo, r to redo):\n", p.PlayerName, convertBoxContent(p.PlayerSymbol))
+
+	return t.readChoice("column")
+}
+
+// readChoice reads the number a user has chosen from command line, or the request to undo or redo a move
+func (t Terminal) readChoice(kind string) (int, error) {
+	for {
+		input, err := t.InputReader.ReadString('\n')
+		if err != nil {
+			return 0, err
+		}
+
+		input = strings.ToLower(strings.TrimSpace(input))
+		switch input {
+		case "u", "undo":
+			return 0, view.ErrUndoRequested
+		case "r", "redo":
+			return 0, view.ErrRedoRequested
+		}
+
+		choice, err := strconv.Atoi(input)
+		if err != nil {
+			fmt.Printf("'%s' is not a %s number, choose again:\n", input, kind)
+			continue
+		}
+
+		return choice, nil
+	}
 }
 
 // DeclareWinner prints out victory message on the command line for the player that has won
@@ -112,11 +244,89 @@ func (t Terminal) DeclareWinner(playerName string) {
 	fmt.Printf("Congratulations %s! You have won.\n", playerName)
 }
 
+// DeclareLoser prints out a message on the command line for the player that has lost by completing a line under misere rules
+func (t Terminal) DeclareLoser(playerName string) {
+	fmt.Printf("%s has completed a line and lost.\n", playerName)
+}
+
+// DeclareInvalidMove prints out on the command line why the move chosen cannot be played
+func (t Terminal) DeclareInvalidMove(reason error) {
+	fmt.Printf("Invalid move: %v, choose again.\n", reason)
+}
+
 // DeclareDraw prints out a message on the command line indicating that the game has ended with a draw
 func (t Terminal) DeclareDraw() {
 	fmt.Println("This game has ended in a draw!")
 }
 
+// DeclareReplayMove prints out on the command line the move a replay has stepped to and who made it
+func (t Terminal) DeclareReplayMove(p view.DeclareReplayMoveParams) {
+	if p.MoveNumber == 0 {
+		fmt.Printf("Start of the game, %d moves recorded\n", p.MoveCount)
+		return
+	}
+
+	fmt.Printf("Move %d of %d: %s played %s\n", p.MoveNumber, p.MoveCount, p.PlayerName, p.Coordinate)
+	if p.CompletesLine {
+		fmt.Printf("%s has completed the line with %s\n", p.PlayerName, p.Coordinate)
+	}
+}
+
+// GetReplayStep gets the user to choose how to step through a recorded game from command line, nothing entered steps to the next move
+func (t Terminal) GetReplayStep() (view.ReplayStep, error) {
+	fmt.Println("Enter n for next, p for previous, f for first, l for last or q to quit:")
+
+	for {
+		input, err := t.InputReader.ReadString('\n')
+		if err != nil {
+			return view.QuitReplay, err
+		}
+
+		input = strings.ToLower(strings.TrimSpace(input))
+		switch input {
+		case "", "n", "next":
+			return view.NextStep, nil
+		case "p", "previous":
+			return view.PreviousStep, nil
+		case "f", "first":
+			return view.FirstStep, nil
+		case "l", "last":
+			return view.LastStep, nil
+		case "q", "quit":
+			return view.QuitReplay, nil
+		}
+
+		fmt.Printf("'%s' is not a step, choose again:\n", input)
+	}
+}
+
+// findWinningBoxes returns the positions of the boxes on the line completed by the last move made on the board, if that move has won the game
+func findWinningBoxes(b *board.Board) map[board.Position]bool {
+	history := b.History()
+	if len(history) == 0 {
+		return nil
+	}
+
+	lastMove := history[len(history)-1]
+	checkForWinnerParams := board.CheckForWinnerParams{
+		PlayerSymbol: lastMove.Content,
+		RowIdx:       lastMove.RowIdx,
+		ColIdx:       lastMove.ColIdx,
+	}
+
+	winningLine, won := b.FindWinningLine(checkForWinnerParams)
+	if !won {
+		return nil
+	}
+
+	winningBoxes := make(map[board.Position]bool, len(winningLine.Positions))
+	for _, position := range winningLine.Positions {
+		winningBoxes[position] = true
+	}
+
+	return winningBoxes
+}
+
 // convertBoxContent converts the box content constant to its string representation on command line
 func convertBoxContent(b board.BoxContent) string {
 	switch b {
@@ -124,6 +334,26 @@ func convertBoxContent(b board.BoxContent) string {
 		return "x"
 	case board.O:
 		return "o"
+	case board.T:
+		return "△"
+	case board.S:
+		return "□"
+	default:
+		return ""
+	}
+}
+
+// highlightBoxContent converts the box content constant to its highlighted string representation on command line
+func highlightBoxContent(b board.BoxContent) string {
+	switch b {
+	case board.X:
+		return "X"
+	case board.O:
+		return "O"
+	case board.T:
+		return "▲"
+	case board.S:
+		return "■"
 	default:
 		return ""
 	}
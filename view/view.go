@@ -2,7 +2,15 @@
 package view
 
 import (
+	"errors"
+
 	"github.com/dev-amos/tictactoe/board"
+	"github.com/dev-amos/tictactoe/player"
+)
+
+var (
+	ErrUndoRequested = errors.New("player asked to take back the last move")
+	ErrRedoRequested = errors.New("player asked to play the last move taken back again")
 )
 
 type GetUserToSelectBoxParams struct {
@@ -10,12 +18,71 @@ type GetUserToSelectBoxParams struct {
 	PlayerSymbol board.BoxContent
 }
 
+// GetSeatParams defines the structure for the parameters needed to ask who plays the nth player's seat
+type GetSeatParams struct {
+	PlayerCount int
+	// Levels are the levels the computer can play the seat at in this game, the other levels cannot be chosen
+	Levels []player.Level
+}
+
+// Offers checks if the computer can be chosen to play the seat at the level
+func (p GetSeatParams) Offers(level player.Level) bool {
+	for _, l := range p.Levels {
+		if l == level {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Seat is who plays a player's seat, a human or the computer at one of its levels
+type Seat struct {
+	Computer bool
+	Level    player.Level // level the computer plays at, empty for a human
+}
+
 // The model that is responsible for taking inputs from the players
 type View interface {
 	DeclareDraw()
 	DeclareWinner(playerName string)
+	DeclareLoser(playerName string)
+	DeclareInvalidMove(reason error)
 	PrintBoard(b board.Board)
-	GetDimensions() (int, error)
+	GetDimensions() (rows int, cols int, err error)
+	GetWinCount() (int, error)
+	GetNumberOfPlayers(maxPlayers int) (int, error)
 	GetUserName(playerCount int) (string, error)
+	GetSeat(p GetSeatParams) (Seat, error)
 	GetUserToSelectBox(p GetUserToSelectBoxParams) (int, error)
+	GetUserToSelectColumn(p GetUserToSelectBoxParams) (int, error)
+}
+
+// ReplayStep is the way a player has chosen to step through a recorded game
+type ReplayStep int
+
+// Ways to step through a recorded game
+const (
+	NextStep     ReplayStep = iota // Play the next move
+	PreviousStep                   // Take back the last move
+	FirstStep                      // Go back to before the first move
+	LastStep                       // Play every move left
+	QuitReplay                     // Stop the replay
+)
+
+// DeclareReplayMoveParams defines the structure for the parameters needed to describe the move a replay has stepped to
+type DeclareReplayMoveParams struct {
+	MoveNumber int
+	MoveCount  int
+	PlayerName string // empty before the first move
+	Coordinate string // a1 style coordinate of the box filled
+	// CompletesLine is set when the move has completed the line that ended the game
+	CompletesLine bool
+}
+
+// Replayer is a view that can also step through a recorded game
+type Replayer interface {
+	View
+	DeclareReplayMove(p DeclareReplayMoveParams)
+	GetReplayStep() (ReplayStep, error)
 }
@@ -0,0 +1,162 @@
+// app.js renders the events streamed by the game and posts the answers to its prompts
+"use strict";
+
+const boardElement = document.getElementById("board");
+const rulesElement = document.getElementById("rules");
+const promptElement = document.getElementById("prompt");
+const promptText = document.getElementById("prompt-text");
+const promptInput = document.getElementById("prompt-input");
+const promptOptions = document.getElementById("prompt-options");
+const messagesElement = document.getElementById("messages");
+
+const buttons = {
+  submit: document.getElementById("prompt-submit"),
+  undo: document.getElementById("prompt-undo"),
+  redo: document.getElementById("prompt-redo"),
+};
+
+let currentBoard = null;
+let currentPrompt = null;
+
+// answer posts the answer to the prompt being shown and hides it until the next prompt arrives
+function answer(value) {
+  if (currentPrompt === null) {
+    return;
+  }
+
+  currentPrompt = null;
+  promptElement.hidden = true;
+  renderBoard();
+
+  fetch("answer", {
+    method: "POST",
+    body: new URLSearchParams({ answer: value }),
+  });
+}
+
+// renderBoard draws the last board received, boxes can be clicked when the player is asked for a box or a column
+function renderBoard() {
+  if (currentBoard === null) {
+    return;
+  }
+
+  const b = currentBoard;
+  const input = currentPrompt === null ? "" : currentPrompt.input;
+  const winning = new Set((b.winningLine || []).map(([row, col]) => row + "," + col));
+
+  const rules = [b.winCount + " in a line to win"];
+  if (b.gravity) {
+    rules.push("gravity");
+  }
+  if (b.misere) {
+    rules.push("misère, completing a line loses");
+  }
+  rulesElement.textContent = b.rows + "x" + b.cols + " board, " + rules.join(", ");
+
+  boardElement.style.gridTemplateColumns = "repeat(" + b.cols + ", minmax(0, 4em))";
+  boardElement.replaceChildren();
+
+  b.boxes.forEach((row, rowIdx) => {
+    row.forEach((content, colIdx) => {
+      const box = document.createElement("button");
+      box.type = "button";
+      box.className = "box";
+
+      const boxNumber = rowIdx * b.cols + colIdx + 1;
+      if (content !== "") {
+        box.textContent = content;
+      } else if (!b.gravity) {
+        box.textContent = boxNumber;
+        box.classList.add("number");
+      }
+
+      if (winning.has(rowIdx + "," + colIdx)) {
+        box.classList.add("winning");
+      }
+
+      if (input === "box" && content === "") {
+        box.onclick = () => answer(String(boxNumber));
+      } else if (input === "column") {
+        box.onclick = () => answer(String(colIdx + 1));
+      } else {
+        box.disabled = true;
+      }
+
+      boardElement.appendChild(box);
+    });
+  });
+}
+
+// renderPrompt shows the question asked and the controls needed to answer it
+function renderPrompt() {
+  const input = currentPrompt.input;
+  const choosing = input === "box" || input === "column";
+
+  promptText.textContent = currentPrompt.text;
+  promptInput.hidden = input === "choice" || choosing;
+  promptInput.type = input === "number" ? "number" : "text";
+  promptInput.value = "";
+  buttons.submit.hidden = input === "choice" || choosing;
+
+  // every answer of a choice gets its own button
+  promptOptions.replaceChildren();
+  (currentPrompt.options || []).forEach((option) => {
+    const button = document.createElement("button");
+    button.type = "button";
+    button.textContent = option;
+    button.onclick = () => answer(option);
+    promptOptions.appendChild(button);
+  });
+
+  buttons.undo.hidden = !choosing;
+  buttons.redo.hidden = !choosing;
+  promptElement.hidden = false;
+
+  if (!promptInput.hidden) {
+    promptInput.focus();
+  }
+}
+
+// addMessage adds a line below the board, such as the result of the game
+function addMessage(text) {
+  const item = document.createElement("li");
+  item.textContent = text;
+  messagesElement.appendChild(item);
+}
+
+promptElement.onsubmit = (e) => {
+  e.preventDefault();
+  answer(promptInput.value);
+};
+buttons.undo.onclick = () => answer("u");
+buttons.redo.onclick = () => answer("r");
+
+const events = new EventSource("events");
+
+// every event is sent again when the stream reconnects, so the page starts over from a blank state
+events.onopen = () => {
+  currentBoard = null;
+  currentPrompt = null;
+  promptElement.hidden = true;
+  boardElement.replaceChildren();
+  messagesElement.replaceChildren();
+};
+
+events.onmessage = (message) => {
+  const e = JSON.parse(message.data);
+
+  switch (e.type) {
+    case "board":
+      currentBoard = e.board;
+      break;
+    case "prompt":
+      currentPrompt = e;
+      renderPrompt();
+      break;
+    case "message":
+      addMessage(e.text);
+      break;
+  }
+
+  renderBoard();
+};
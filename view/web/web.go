@@ -0,0 +1,442 @@
+// Package web lets the game be played from a browser, the page is served from embedded assets and kept in sync with server-sent events
+//
+//	GET  /        the page that renders the board and the prompts
+//	GET  /events  stream of events, each one a JSON object sent as the data of a server-sent event
+//	POST /answer  answers the prompt the page is showing, the answer is sent as the form value "answer"
+//
+// every view method sends an event to the pages that are open, the methods asking for input wait until one of them answers
+package web
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dev-amos/tictactoe/board"
+	"github.com/dev-amos/tictactoe/player"
+	"github.com/dev-amos/tictactoe/view"
+)
+
+var (
+	ErrNoPrompt          = errors.New("there is no question waiting for an answer")
+	ErrStreamUnsupported = errors.New("response does not support streaming events")
+)
+
+// event types sent to the page
+const (
+	boardEvent   = "board"
+	promptEvent  = "prompt"
+	messageEvent = "message"
+)
+
+// input types of a prompt, they tell the page which control to show
+const (
+	textInput   = "text"
+	numberInput = "number"
+	choiceInput = "choice"
+	boxInput    = "box"
+	columnInput = "column"
+)
+
+// subscriberBuffer is the number of events that can be waiting to be written to a page before it is dropped and left to reconnect
+const subscriberBuffer = 64
+
+//go:embed static
+var static embed.FS
+
+// Web is a view for a game played from a browser, it is safe for use by the game and the HTTP handlers at the same time
+type Web struct {
+	mu          sync.Mutex
+	history     []event
+	prompt      *event
+	subscribers map[chan event]bool
+	answers     chan string
+	assets      http.Handler
+}
+
+// event is a single update sent to the page
+type event struct {
+	Type    string        `json:"type"`
+	Text    string        `json:"text,omitempty"`
+	Input   string        `json:"input,omitempty"`
+	Symbol  string        `json:"symbol,omitempty"`
+	Options []string      `json:"options,omitempty"` // answers to choose from when the input is a choice
+	Board   *boardContent `json:"board,omitempty"`
+}
+
+// boardContent is the board as the page renders it, boxes hold the same symbols as the terminal
+type boardContent struct {
+	Rows        int        `json:"rows"`
+	Cols        int        `json:"cols"`
+	WinCount    int        `json:"winCount"`
+	Gravity     bool       `json:"gravity"`
+	Misere      bool       `json:"misere"`
+	Boxes       [][]string `json:"boxes"`
+	WinningLine [][2]int   `json:"winningLine,omitempty"`
+}
+
+// NewWeb creates a web view, it has to be served over HTTP for a page to be able to play
+func NewWeb() *Web {
+	assets, err := fs.Sub(static, "static")
+	if err != nil {
+		panic(err)
+	}
+
+	return &Web{
+		subscribers: make(map[chan event]bool),
+		answers:     make(chan string, 1),
+		assets:      http.FileServer(http.FS(assets)),
+	}
+}
+
+// ServeHTTP serves the page, the stream of events and the answers to prompts
+func (w *Web) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/events":
+		w.streamEvents(rw, r)
+	case "/answer":
+		if r.Method != http.MethodPost {
+			http.Error(rw, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		w.receiveAnswer(rw, r)
+	default:
+		w.assets.ServeHTTP(rw, r)
+	}
+}
+
+// PrintBoard shows the board on the page, the boxes of the line completed by the last move are highlighted
+func (w *Web) PrintBoard(b board.Board) {
+	content := &boardContent{
+		Rows:     b.Rows(),
+		Cols:     b.Cols(),
+		WinCount: b.WinCount,
+		Gravity:  b.Gravity,
+		Misere:   b.Misere,
+		Boxes:    make([][]string, b.Rows()),
+	}
+
+	for row := range b.Boxes {
+		content.Boxes[row] = make([]string, b.Cols())
+		for col := range b.Boxes[row] {
+			content.Boxes[row][col] = convertBoxContent(b.Boxes[row][col])
+		}
+	}
+
+	for _, position := range findWinningLine(&b).Positions {
+		content.WinningLine = append(content.WinningLine, [2]int{position.RowIdx, position.ColIdx})
+	}
+
+	w.publish(event{Type: boardEvent, Board: content})
+}
+
+// GetDimensions asks the page for the number of rows and columns of the board, entered as rows x cols, such as 6x7, or as a single number for a square board
+func (w *Web) GetDimensions() (int, int, error) {
+	text := "Enter game dimensions for tictactoe (e.g. 3 or 6x7):"
+
+	for {
+		input := strings.ToLower(w.ask(event{Type: promptEvent, Text: text, Input: textInput}))
+		dimensions := strings.SplitN(input, "x", 2)
+
+		rows, err := strconv.Atoi(strings.TrimSpace(dimensions[0]))
+		if err != nil {
+			text = fmt.Sprintf("'%s' is not a dimension, enter game dimensions again (e.g. 3 or 6x7):", input)
+			continue
+		}
+
+		if len(dimensions) == 1 {
+			return rows, rows, nil
+		}
+
+		cols, err := strconv.Atoi(strings.TrimSpace(dimensions[1]))
+		if err != nil {
+			text = fmt.Sprintf("'%s' is not a dimension, enter game dimensions again (e.g. 3 or 6x7):", input)
+			continue
+		}
+
+		return rows, cols, nil
+	}
+}
+
+// GetWinCount asks the page for the number of boxes in a line a player needs to fill to win
+func (w *Web) GetWinCount() (int, error) {
+	return w.askNumber(event{Type: promptEvent, Text: "Enter number of boxes in a line needed to win:", Input: numberInput}, "")
+}
+
+// GetNumberOfPlayers asks the page for the number of players joining the game, defaulting to 2 when nothing is entered
+// a number of players that is not between 2 and the max players is asked for again
+func (w *Web) GetNumberOfPlayers(maxPlayers int) (int, error) {
+	text := fmt.Sprintf("Enter number of players (2-%d):", maxPlayers)
+	e := event{Type: promptEvent, Text: text, Input: numberInput}
+
+	for {
+		numberOfPlayers, err := w.askNumber(e, "2")
+		if err != nil || numberOfPlayers >= 2 && numberOfPlayers <= maxPlayers {
+			return numberOfPlayers, err
+		}
+
+		e.Text = fmt.Sprintf("'%d' is not between 2 and %d. %s", numberOfPlayers, maxPlayers, text)
+	}
+}
+
+// GetUserName asks the page for the nth player name
+func (w *Web) GetUserName(playerCount int) (string, error) {
+	return w.ask(event{Type: promptEvent, Text: fmt.Sprintf("Enter name for Player %d", playerCount), Input: textInput}), nil
+}
+
+// GetSeat asks the page who plays the nth player's seat, a human or the computer at one of its levels, defaulting to a human when nothing is chosen
+// only the levels that can be played in this game are offered, any other answer is asked for again
+func (w *Web) GetSeat(p view.GetSeatParams) (view.Seat, error) {
+	text := fmt.Sprintf("Who plays Player %d?", p.PlayerCount)
+
+	options := []string{"human"}
+	for _, level := range p.Levels {
+		options = append(options, fmt.Sprintf("%s computer", level))
+	}
+
+	e := event{Type: promptEvent, Text: text, Input: choiceInput, Options: options}
+	for {
+		input := strings.ToLower(w.ask(e))
+		if input == "" || input == "human" {
+			return view.Seat{}, nil
+		}
+
+		if level, err := player.ParseLevel(strings.TrimSuffix(input, " computer")); err == nil && p.Offers(level) {
+			return view.Seat{Computer: true, Level: level}, nil
+		}
+
+		e.Text = fmt.Sprintf("'%s' is not a choice. %s", input, text)
+	}
+}
+
+// GetUserToSelectBox gets the player to click on a numbered box of the board to select their move, or to undo or redo a move instead
+func (w *Web) GetUserToSelectBox(p view.GetUserToSelectBoxParams) (int, error) {
+	symbol := convertBoxContent(p.PlayerSymbol)
+
+	return w.askChoice(event{Type: promptEvent, Text: fmt.Sprintf("%s, choose a box to place an '%s' into", p.PlayerName, symbol), Input: boxInput, Symbol: symbol})
+}
+
+// GetUserToSelectColumn gets the player to click on a column of a board with gravity to drop their symbol into, or to undo or redo a move instead
+func (w *Web) GetUserToSelectColumn(p view.GetUserToSelectBoxParams) (int, error) {
+	symbol := convertBoxContent(p.PlayerSymbol)
+
+	return w.askChoice(event{Type: promptEvent, Text: fmt.Sprintf("%s, choose a column to drop an '%s' into", p.PlayerName, symbol), Input: columnInput, Symbol: symbol})
+}
+
+// DeclareWinner shows the victory message on the page for the player that has won
+func (w *Web) DeclareWinner(playerName string) {
+	w.publish(event{Type: messageEvent, Text: fmt.Sprintf("Congratulations %s! You have won.", playerName)})
+}
+
+// DeclareLoser shows a message on the page for the player that has lost by completing a line under misere rules
+func (w *Web) DeclareLoser(playerName string) {
+	w.publish(event{Type: messageEvent, Text: fmt.Sprintf("%s has completed a line and lost.", playerName)})
+}
+
+// DeclareInvalidMove shows on the page why the move chosen cannot be played
+func (w *Web) DeclareInvalidMove(reason error) {
+	w.publish(event{Type: messageEvent, Text: fmt.Sprintf("Invalid move: %v, choose again.", reason)})
+}
+
+// DeclareDraw shows a message on the page indicating that the game has ended with a draw
+func (w *Web) DeclareDraw() {
+	w.publish(event{Type: messageEvent, Text: "This game has ended in a draw!"})
+}
+
+// askNumber asks the page for a number, anything that is not a number is asked for again and nothing entered returns the default when there is one
+func (w *Web) askNumber(e event, defaultInput string) (int, error) {
+	text := e.Text
+
+	for {
+		input := w.ask(e)
+		if input == "" {
+			input = defaultInput
+		}
+
+		number, err := strconv.Atoi(input)
+		if err != nil {
+			e.Text = fmt.Sprintf("'%s' is not a number. %s", input, text)
+			continue
+		}
+
+		return number, nil
+	}
+}
+
+// askChoice asks the page for the number of a box or column, or for the request to undo or redo a move
+func (w *Web) askChoice(e event) (int, error) {
+	text := e.Text
+
+	for {
+		input := strings.ToLower(w.ask(e))
+		switch input {
+		case "u", "undo":
+			return 0, view.ErrUndoRequested
+		case "r", "redo":
+			return 0, view.ErrRedoRequested
+		}
+
+		choice, err := strconv.Atoi(input)
+		if err != nil {
+			e.Text = fmt.Sprintf("'%s' is not a %s number. %s", input, e.Input, text)
+			continue
+		}
+
+		return choice, nil
+	}
+}
+
+// ask shows the prompt on the page and waits until it is answered
+func (w *Web) ask(e event) string {
+	w.mu.Lock()
+	w.prompt = &e
+	w.broadcast(e)
+	w.mu.Unlock()
+
+	return strings.TrimSpace(<-w.answers)
+}
+
+// publish sends the event to the pages that are open and keeps it for the pages opened later
+func (w *Web) publish(e event) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.history = append(w.history, e)
+	w.broadcast(e)
+}
+
+// broadcast sends the event to every page that is open, the caller must hold the lock
+// a page that falls behind is disconnected, the browser reconnects on its own and is sent every event again
+func (w *Web) broadcast(e event) {
+	for subscriber := range w.subscribers {
+		select {
+		case subscriber <- e:
+		default:
+			delete(w.subscribers, subscriber)
+			close(subscriber)
+		}
+	}
+}
+
+// subscribe returns the channel a page receives events on, it starts with the events sent so far and the prompt waiting for an answer
+func (w *Web) subscribe() chan event {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	subscriber := make(chan event, len(w.history)+subscriberBuffer)
+	for _, e := range w.history {
+		subscriber <- e
+	}
+	if w.prompt != nil {
+		subscriber <- *w.prompt
+	}
+
+	w.subscribers[subscriber] = true
+
+	return subscriber
+}
+
+// unsubscribe stops sending events to the channel of a page that has been closed
+func (w *Web) unsubscribe(subscriber chan event) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.subscribers[subscriber] {
+		delete(w.subscribers, subscriber)
+		close(subscriber)
+	}
+}
+
+// streamEvents writes every event to the page as a server-sent event until the page is closed
+func (w *Web) streamEvents(rw http.ResponseWriter, r *http.Request) {
+	flusher, ok := rw.(http.Flusher)
+	if !ok {
+		http.Error(rw, ErrStreamUnsupported.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	subscriber := w.subscribe()
+	defer w.unsubscribe(subscriber)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-subscriber:
+			if !ok {
+				return
+			}
+
+			data, err := json.Marshal(e)
+			if err != nil {
+				return
+			}
+
+			if _, err := fmt.Fprintf(rw, "data: %s\n\n", data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// receiveAnswer hands the answer posted by the page to the view method waiting for it
+func (w *Web) receiveAnswer(rw http.ResponseWriter, r *http.Request) {
+	w.mu.Lock()
+	if w.prompt == nil {
+		w.mu.Unlock()
+		http.Error(rw, ErrNoPrompt.Error(), http.StatusConflict)
+		return
+	}
+	w.prompt = nil
+	w.mu.Unlock()
+
+	w.answers <- r.FormValue("answer")
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// findWinningLine returns the line completed by the last move made on the board, it has no positions when that move has not won the game
+func findWinningLine(b *board.Board) board.WinningLine {
+	history := b.History()
+	if len(history) == 0 {
+		return board.WinningLine{}
+	}
+
+	lastMove := history[len(history)-1]
+	checkForWinnerParams := board.CheckForWinnerParams{
+		PlayerSymbol: lastMove.Content,
+		RowIdx:       lastMove.RowIdx,
+		ColIdx:       lastMove.ColIdx,
+	}
+
+	winningLine, _ := b.FindWinningLine(checkForWinnerParams)
+
+	return winningLine
+}
+
+// convertBoxContent converts the box content constant to the symbol shown on the page
+func convertBoxContent(b board.BoxContent) string {
+	switch b {
+	case board.X:
+		return "x"
+	case board.O:
+		return "o"
+	case board.T:
+		return "△"
+	case board.S:
+		return "□"
+	default:
+		return ""
+	}
+}
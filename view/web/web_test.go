@@ -0,0 +1,296 @@
+package web
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dev-amos/tictactoe/board"
+	"github.com/dev-amos/tictactoe/player"
+	"github.com/dev-amos/tictactoe/view"
+)
+
+// readEvent reads the next server-sent event from the stream
+func readEvent(t *testing.T, reader *bufio.Reader) event {
+	t.Helper()
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("unexpected error = %v, want %v", err, nil)
+		}
+
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+
+		var e event
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &e); err != nil {
+			t.Fatalf("unexpected error = %v, want %v", err, nil)
+		}
+
+		return e
+	}
+}
+
+// postAnswer answers the prompt shown on the page
+func postAnswer(t *testing.T, serverURL string, answer string) int {
+	t.Helper()
+
+	res, err := http.PostForm(serverURL+"/answer", url.Values{"answer": {answer}})
+	if err != nil {
+		t.Fatalf("unexpected error = %v, want %v", err, nil)
+	}
+	res.Body.Close()
+
+	return res.StatusCode
+}
+
+func TestServePage(t *testing.T) {
+	server := httptest.NewServer(NewWeb())
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/")
+	if err != nil {
+		t.Fatalf("unexpected error = %v, want %v", err, nil)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("unexpected status code = %d, want %d", res.StatusCode, http.StatusOK)
+	}
+
+	if contentType := res.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "text/html") {
+		t.Errorf("unexpected content type = %s, want %s", contentType, "text/html")
+	}
+
+}
+
+func TestPromptsOverEvents(t *testing.T) {
+	type result struct {
+		choice int
+		err    error
+	}
+
+	tests := []struct {
+		name    string
+		answers []string
+		want    result
+	}{
+		{
+			"returns the box clicked",
+			[]string{"5"},
+			result{5, nil},
+		},
+		{
+			"asks again when the answer is not a number",
+			[]string{"five", "5"},
+			result{5, nil},
+		},
+		{
+			"returns undo request",
+			[]string{"u"},
+			result{0, view.ErrUndoRequested},
+		},
+		{
+			"returns redo request",
+			[]string{"redo"},
+			result{0, view.ErrRedoRequested},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := NewWeb()
+			server := httptest.NewServer(w)
+			defer server.Close()
+
+			b, _ := board.NewBoard(board.NewBoardParams{WinCount: 3, Rows: 3, Cols: 3})
+			b.SelectBox(board.InsertBoxWithContentParams{RowIdx: 0, ColIdx: 0, Content: board.X})
+
+			// the board is printed before the page is opened, the page is sent it when it connects
+			w.PrintBoard(*b)
+
+			res, err := http.Get(server.URL + "/events")
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+			defer res.Body.Close()
+			reader := bufio.NewReader(res.Body)
+
+			e := readEvent(t, reader)
+			wantBoxes := [][]string{{"x", "", ""}, {"", "", ""}, {"", "", ""}}
+			if e.Type != boardEvent || !reflect.DeepEqual(e.Board.Boxes, wantBoxes) {
+				t.Errorf("unexpected event = %v, want board with boxes %v", e, wantBoxes)
+			}
+
+			results := make(chan result, 1)
+			go func() {
+				choice, err := w.GetUserToSelectBox(view.GetUserToSelectBoxParams{PlayerName: "amos", PlayerSymbol: board.O})
+				results <- result{choice, err}
+			}()
+
+			for _, answer := range test.answers {
+				if e := readEvent(t, reader); e.Type != promptEvent || e.Input != boxInput || e.Symbol != "o" {
+					t.Errorf("unexpected event = %v, want prompt for a box", e)
+				}
+
+				if statusCode := postAnswer(t, server.URL, answer); statusCode != http.StatusNoContent {
+					t.Errorf("unexpected status code = %d, want %d", statusCode, http.StatusNoContent)
+				}
+			}
+
+			if got := <-results; !reflect.DeepEqual(got, test.want) {
+				t.Errorf("unexpected result = %v, want %v", got, test.want)
+			}
+
+			if statusCode := postAnswer(t, server.URL, "1"); statusCode != http.StatusConflict {
+				t.Errorf("unexpected status code = %d, want %d", statusCode, http.StatusConflict)
+			}
+
+		})
+	}
+
+}
+
+func TestGetSeat(t *testing.T) {
+	tests := []struct {
+		name    string
+		levels  []player.Level
+		answers []string
+		want    view.Seat
+	}{
+		{
+			"returns a human seat",
+			player.Levels,
+			[]string{"human"},
+			view.Seat{},
+		},
+		{
+			"returns a computer seat at the level chosen",
+			player.Levels,
+			[]string{"hard computer"},
+			view.Seat{Computer: true, Level: player.Hard},
+		},
+		{
+			"asks again when the answer is not a choice",
+			player.Levels,
+			[]string{"robot", "easy computer"},
+			view.Seat{Computer: true, Level: player.Easy},
+		},
+		{
+			"asks again when the level cannot be played in the game",
+			[]player.Level{player.Easy, player.Medium, player.Hard},
+			[]string{"perfect computer", "hard computer"},
+			view.Seat{Computer: true, Level: player.Hard},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := NewWeb()
+			server := httptest.NewServer(w)
+			defer server.Close()
+
+			res, err := http.Get(server.URL + "/events")
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+			defer res.Body.Close()
+			reader := bufio.NewReader(res.Body)
+
+			seats := make(chan view.Seat, 1)
+			go func() {
+				seat, _ := w.GetSeat(view.GetSeatParams{PlayerCount: 2, Levels: test.levels})
+				seats <- seat
+			}()
+
+			for _, answer := range test.answers {
+				if e := readEvent(t, reader); e.Type != promptEvent || e.Input != choiceInput || len(e.Options) != len(test.levels)+1 {
+					t.Errorf("unexpected event = %v, want prompt with a choice of seats", e)
+				}
+
+				if statusCode := postAnswer(t, server.URL, answer); statusCode != http.StatusNoContent {
+					t.Errorf("unexpected status code = %d, want %d", statusCode, http.StatusNoContent)
+				}
+			}
+
+			if got := <-seats; got != test.want {
+				t.Errorf("unexpected seat = %v, want %v", got, test.want)
+			}
+
+		})
+	}
+
+}
+
+func TestGetNumberOfPlayers(t *testing.T) {
+	tests := []struct {
+		name    string
+		answers []string
+		want    int
+	}{
+		{
+			"returns the number of players entered",
+			[]string{"3"},
+			3,
+		},
+		{
+			"returns 2 when nothing is entered",
+			[]string{""},
+			2,
+		},
+		{
+			"asks again when the answer is not a number",
+			[]string{"three", "3"},
+			3,
+		},
+		{
+			"asks again when the number of players is out of range",
+			[]string{"1", "5", "4"},
+			4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := NewWeb()
+			server := httptest.NewServer(w)
+			defer server.Close()
+
+			res, err := http.Get(server.URL + "/events")
+			if err != nil {
+				t.Fatalf("unexpected error = %v, want %v", err, nil)
+			}
+			defer res.Body.Close()
+			reader := bufio.NewReader(res.Body)
+
+			numbers := make(chan int, 1)
+			go func() {
+				number, _ := w.GetNumberOfPlayers(4)
+				numbers <- number
+			}()
+
+			for _, answer := range test.answers {
+				if e := readEvent(t, reader); e.Type != promptEvent || e.Input != numberInput {
+					t.Errorf("unexpected event = %v, want prompt for a number", e)
+				}
+
+				if statusCode := postAnswer(t, server.URL, answer); statusCode != http.StatusNoContent {
+					t.Errorf("unexpected status code = %d, want %d", statusCode, http.StatusNoContent)
+				}
+			}
+
+			if got := <-numbers; got != test.want {
+				t.Errorf("unexpected number of players = %d, want %d", got, test.want)
+			}
+
+		})
+	}
+
+}